@@ -1,7 +1,18 @@
 const config = {
     user: 'user1',
     channel: 'mychannel',
-    chaincode: 'walletdemo'
+    chaincode: 'walletdemo',
+    // attributes embedded in the certificates of the registered users, read by the chaincode
+    attributes: {
+        'wallet.role': 'user',
+        'wallet.kycLevel': '1'
+    },
+    // enrollment IDs of the designated issuers, only they may add assets
+    issuers: [],
+    // attributes embedded in the certificates of the designated issuers on top of the ones above
+    issuerAttributes: {
+        'issuer': 'true'
+    }
 };
 
 module.exports = config;
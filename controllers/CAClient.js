@@ -8,6 +8,7 @@ const FabricCAServices = require('fabric-ca-client');
 const { FileSystemWallet, X509WalletMixin, Gateway } = require('fabric-network');
 const fs = require('fs');
 const path = require('path');
+const config = require('../config/config.js');
 
 const ccpPath = path.resolve(__dirname, '..', 'config', 'connection-org1.json');
 const ccpJSON = fs.readFileSync(ccpPath, 'utf8');
@@ -93,7 +94,9 @@ class CAClient {
             const adminIdentity = gateway.getCurrentIdentity();
 
             // Register the user, enroll the user, and import the new identity into the wallet.
-            await ca.register({ affiliation: 'org1.department1', enrollmentID: username, enrollmentSecret: secret, role: 'client' }, adminIdentity);
+            const attributes = Object.assign({}, config.attributes, config.issuers.includes(username) ? config.issuerAttributes : {});
+            const attrs = Object.keys(attributes).map(name => ({ name: name, value: attributes[name], ecert: true }));
+            await ca.register({ affiliation: 'org1.department1', enrollmentID: username, enrollmentSecret: secret, role: 'client', attrs: attrs }, adminIdentity);
             const enrollment = await ca.enroll({ enrollmentID: username, enrollmentSecret: secret });
             const userIdentity = X509WalletMixin.createIdentity('Org1MSP', enrollment.certificate, enrollment.key.toBytes());
             await wallet.import(username, userIdentity);
@@ -21,8 +21,9 @@ class FabricOperation {
      * @param string chaincode_name  chaincode name
      * @param string function_name function name
      * @param json data data for query
+     * @param json transient private data passed through the transient map
      */
-    async query(user, channel_name, chaincode_name, function_name, data = null) {
+    async query(user, channel_name, chaincode_name, function_name, data = null, transient = null) {
         try {
             this.userExists(user);
 
@@ -39,7 +40,11 @@ class FabricOperation {
             let result;
 
             // Submit the specified transaction.
-            if (data) {
+            if (transient) {
+                let transaction = contract.createTransaction(function_name).setTransient(this.transientMap(transient));
+                result = data ? await transaction.evaluate(JSON.stringify(data)) : await transaction.evaluate();
+            }
+            else if (data) {
                 result = await contract.evaluateTransaction(function_name, JSON.stringify(data));
             }
             else {
@@ -66,8 +71,9 @@ class FabricOperation {
      * @param string chaincode_name  chaincode name
      * @param string function_name function name
      * @param json data data for query
+     * @param json transient private data passed through the transient map
      */
-    async invoke(user, channel_name, chaincode_name, function_name, data) {
+    async invoke(user, channel_name, chaincode_name, function_name, data, transient = null) {
         try {
             this.userExists(user);
             // Create a new gateway for connecting to our peer node.
@@ -82,7 +88,12 @@ class FabricOperation {
 
             // Submit the specified transaction.
             let result;
-            result = await contract.submitTransaction(function_name, JSON.stringify(data));
+            if (transient) {
+                result = await contract.createTransaction(function_name).setTransient(this.transientMap(transient)).submit(JSON.stringify(data));
+            }
+            else {
+                result = await contract.submitTransaction(function_name, JSON.stringify(data));
+            }
             // Disconnect from the gateway.
             await gateway.disconnect();
             return {
@@ -109,6 +120,18 @@ class FabricOperation {
         return true;
     }
 
+    /**
+     * Converts the private data into the transient map, which is never written to the ledger
+     * @param json transient private data keyed by the transient map entry
+     */
+    transientMap(transient) {
+        let map = {};
+        for (let key in transient) {
+            map[key] = Buffer.from(JSON.stringify(transient[key]));
+        }
+        return map;
+    }
+
     /**
      * Checks whether a string is JSON or not
      * @param {*} item
@@ -149,6 +172,7 @@ class FabricOperation {
                 if (errors) {
                     // make the response
                     response.data.message = errors.msg;
+                    response.data.error_code = errors.error_code;
                     response.status = errors.code;
                 }
                 else {
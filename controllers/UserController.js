@@ -13,10 +13,14 @@ class UserController {
             const secret = this.makeid(300);
             let result = await CAClientController.enrollUser(data.user, secret);
             if (result.status = 200) {
-                data.secret = result.secret;
-                data.identity = data.user;
+                // the secret and identity are kept in the private data collection
+                const transient = { user: { secret: result.secret, identity: data.user } };
                 // Invoke the chaincode function
-                let response = await FabricController.invoke(data.user, config.channel, config.chaincode, 'createUser', data);
+                let response = await FabricController.invoke(data.user, config.channel, config.chaincode, 'createUser', data, transient);
+                if (response.status == 200) {
+                    response.data.data.identity = data.user;
+                    response.data.data.secret = result.secret + data.user;
+                }
                 return response;
             }
             else {
@@ -41,8 +45,9 @@ class UserController {
     async getUser(data) {
         const str = data.secret;
         const identity = str.substring(300);
-        // Invoke the chaincode function
-        let response = await FabricController.invoke(identity, config.channel, config.chaincode, 'getUser', data);
+        const transient = { user: { secret: data.secret } };
+        // Query the chaincode function
+        let response = await FabricController.query(identity, config.channel, config.chaincode, 'getUser', null, transient);
         return response;
     }
 
@@ -4,13 +4,17 @@ package main
 import (
 	"fmt"
 
+	"github.com/chaincode/demo-network/pkg/core/auth"
+	"github.com/chaincode/demo-network/pkg/core/middleware"
+	"github.com/chaincode/demo-network/pkg/core/rbac"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
 	"github.com/chaincode/demo-network/pkg/users"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/s7techlab/cckit/extensions/owner"
 	"github.com/s7techlab/cckit/router"
-	"github.com/s7techlab/cckit/router/param"
 )
 
 // Chaincode default chaincode implementation with router
@@ -36,6 +40,9 @@ func New() *Chaincode {
 	r := router.New("Chaincode")
 	chaincode := &Chaincode{r}
 
+	// Log every invocation and turn the panics into internal errors
+	r.Use(middleware.Localize, middleware.Log, middleware.Recover)
+
 	// Handle the init/upgrade
 	r.Init(invokeInit)
 
@@ -43,16 +50,127 @@ func New() *Chaincode {
 
 	/***** users routes *****/
 
-	r.Invoke(`createUser`, users.CreateUser, param.Struct(`data`, &users.User{}))
-	r.Invoke(`getUser`, users.GetUser, param.Struct(`data`, &users.UserSecret{}))
-	r.Invoke(`getUsers`, users.GetUsers, param.Struct(`data`, &users.UserId{}))
-	r.Invoke(`getAssets`, users.GetAssets, param.Struct(`data`, &users.UserId{}))
-	r.Invoke(`addAsset`, users.AddAsset, param.Struct(`data`, &users.Asset{}))
-	r.Invoke(`checkAsset`, users.CheckAsset, param.Struct(`data`, &users.CheckAssetStruct{}))
-	r.Invoke(`transferAsset`, users.TransferAsset, param.Struct(`data`, &users.GetTransaction{}))
-	r.Invoke(`addAddress`, users.AddAddress, param.Struct(`data`, &users.Address{}))
-	r.Invoke(`sendBalance`, users.TransferBalance, param.Struct(`data`, &users.SendBalance{}))
-	r.Invoke(`getLabel`, users.GetAddressBookLabel, param.Struct(`data`, &users.AddressBook{}))
+	r.Invoke(`createUser`, users.CreateUser, middleware.Struct(`data`, &users.User{}), middleware.Idempotent)
+	r.Query(`getUser`, users.GetUser)
+	r.Invoke(`migrateUserSecrets`, users.MigrateUserSecrets, rbac.Only(utils.RoleOperator), middleware.Idempotent)
+	r.Invoke(`migrateUserOwners`, users.MigrateUserOwners, rbac.Only(utils.RoleOperator), middleware.Idempotent)
+	r.Invoke(`getUsers`, users.GetUsers, middleware.Struct(`data`, &users.UserId{}))
+	r.Invoke(`getAssets`, users.GetAssets, middleware.Struct(`data`, &users.UserId{}))
+	r.Invoke(`addAsset`, users.AddAsset, middleware.Struct(`data`, &users.Asset{}), auth.Require(auth.Equals(utils.AttrIssuer, "true")), middleware.Idempotent)
+	r.Invoke(`checkAsset`, users.CheckAsset, middleware.Struct(`data`, &users.CheckAssetStruct{}))
+	r.Invoke(`transferAsset`, users.TransferAsset, middleware.Struct(`data`, &users.GetTransaction{}), middleware.Idempotent, users.Screened)
+	r.Invoke(`addAddress`, users.AddAddress, middleware.Struct(`data`, &users.Address{}), middleware.Idempotent, users.Screened)
+	r.Invoke(`renameAddress`, users.RenameAddress, middleware.Struct(`data`, &users.Address{}), middleware.Idempotent)
+	r.Invoke(`retireAddress`, users.RetireAddress, middleware.Struct(`data`, &users.AddressValue{}), middleware.Idempotent)
+	r.Invoke(`setPrimaryAddress`, users.SetPrimaryAddress, middleware.Struct(`data`, &users.AddressValue{}), middleware.Idempotent)
+	r.Invoke(`sendBalance`, users.TransferBalance, middleware.Struct(`data`, &users.SendBalance{}), middleware.Idempotent, users.Screened)
+	r.Invoke(`moveBalance`, users.MoveBalance, middleware.Struct(`data`, &users.InternalMove{}), middleware.Idempotent, users.Screened)
+	r.Invoke(`migrateSubAccounts`, users.MigrateSubAccounts, rbac.Only(utils.RoleOperator), middleware.Idempotent)
+	r.Invoke(`migrateAmounts`, users.MigrateAmounts, rbac.Only(utils.RoleOperator), middleware.Idempotent)
+	r.Invoke(`setMemoKey`, users.SetMemoKey, middleware.Struct(`data`, &users.MemoKey{}), middleware.Idempotent)
+	r.Invoke(`getLabel`, users.GetAddressBookLabel, middleware.Struct(`data`, &users.AddressBook{}))
+
+	/***** journal routes *****/
+
+	r.Query(`getTrialBalance`, users.GetTrialBalance, rbac.Only(utils.RoleAuditor))
+
+	/***** confidential transfer routes *****/
+
+	r.Invoke(`depositConfidential`, users.DepositConfidential, middleware.Struct(`data`, &users.ConfidentialFunds{}), middleware.Idempotent, users.Screened)
+	r.Invoke(`withdrawConfidential`, users.WithdrawConfidential, middleware.Struct(`data`, &users.ConfidentialFunds{}), middleware.Idempotent, users.Screened)
+	r.Query(`getConfidentialBalance`, users.GetConfidentialBalance, middleware.Struct(`data`, &users.ConfidentialBalance{}))
+	r.Invoke(`confidentialTransfer`, users.TransferConfidential, middleware.Struct(`data`, &users.ConfidentialTransfer{}), middleware.Idempotent, users.Screened)
+	r.Query(`verifyConfidentialTransfer`, users.VerifyConfidentialTransfer, middleware.Struct(`data`, &users.CommitmentReference{}))
+
+	/***** address book routes *****/
+
+	r.Invoke(`createContact`, users.CreateContact, middleware.Struct(`data`, &users.Contact{}), middleware.Idempotent)
+	r.Invoke(`updateContact`, users.UpdateContact, middleware.Struct(`data`, &users.Contact{}), middleware.Idempotent)
+	r.Invoke(`deleteContact`, users.DeleteContact, middleware.Struct(`data`, &users.ContactAddress{}), middleware.Idempotent)
+	r.Query(`listContacts`, users.ListContacts, middleware.Struct(`data`, &users.UserId{}))
+	r.Invoke(`importContacts`, users.ImportContacts, middleware.Struct(`data`, &users.ContactsImport{}), middleware.Idempotent)
+	r.Query(`exportContacts`, users.ExportContacts, middleware.Struct(`data`, &users.ContactsExport{}))
+
+	/***** payment request routes *****/
+
+	r.Invoke(`createPaymentRequest`, users.CreatePaymentRequest, middleware.Struct(`data`, &users.PaymentRequest{}), middleware.Idempotent)
+	r.Query(`listPaymentRequests`, users.ListPaymentRequests, middleware.Struct(`data`, &users.PaymentRequestFilter{}))
+	r.Invoke(`payPaymentRequest`, users.PayPaymentRequest, middleware.Struct(`data`, &users.PaymentRequestPayment{}), middleware.Idempotent, users.Screened)
+	r.Invoke(`declinePaymentRequest`, users.DeclinePaymentRequest, middleware.Struct(`data`, &users.PaymentRequestID{}), middleware.Idempotent)
+
+	/***** pending transfer routes *****/
+
+	r.Invoke(`setTransferAcceptance`, users.SetTransferAcceptance, middleware.Struct(`data`, &users.TransferAcceptance{}), middleware.Idempotent)
+	r.Query(`listPendingIncoming`, users.ListPendingIncoming, middleware.Struct(`data`, &users.UserId{}))
+	r.Invoke(`acceptPendingTransfer`, users.AcceptPendingTransfer, middleware.Struct(`data`, &users.PendingTransferID{}), middleware.Idempotent, users.Screened)
+	r.Invoke(`rejectPendingTransfer`, users.RejectPendingTransfer, middleware.Struct(`data`, &users.PendingTransferID{}), middleware.Idempotent, users.Screened)
+	r.Invoke(`refundPendingTransfer`, users.RefundPendingTransfer, middleware.Struct(`data`, &users.PendingTransferID{}), middleware.Idempotent, users.Screened)
+
+	/***** restricted asset routes *****/
+
+	r.Invoke(`setAssetRestriction`, users.SetAssetRestriction, middleware.Struct(`data`, &users.AssetRestriction{}), middleware.Idempotent)
+	r.Query(`listRestrictedTransfers`, users.ListRestrictedTransfers, middleware.Struct(`data`, &users.UserId{}))
+	r.Invoke(`approveRestrictedTransfer`, users.ApproveRestrictedTransfer, middleware.Struct(`data`, &users.TransferReview{}), middleware.Idempotent, users.Screened)
+	r.Invoke(`rejectRestrictedTransfer`, users.RejectRestrictedTransfer, middleware.Struct(`data`, &users.TransferReview{}), middleware.Idempotent)
+
+	/***** allowlist routes *****/
+
+	r.Invoke(`setAllowlistMode`, users.SetAllowlistMode, middleware.Struct(`data`, &users.AllowlistMode{}), middleware.Idempotent)
+	r.Invoke(`addAllowlistAddresses`, users.AddAllowlistAddresses, middleware.Struct(`data`, &users.AllowlistAddresses{}), middleware.Idempotent)
+	r.Invoke(`removeAllowlistAddresses`, users.RemoveAllowlistAddresses, middleware.Struct(`data`, &users.AllowlistAddresses{}), middleware.Idempotent)
+	r.Query(`getAllowlist`, users.GetAllowlist, middleware.Struct(`data`, &users.AllowlistQuery{}))
+
+	/***** hold routes *****/
+
+	r.Invoke(`placeHold`, users.PlaceHold, middleware.Struct(`data`, &users.Hold{}), middleware.Idempotent)
+	r.Invoke(`captureHold`, users.CaptureHold, middleware.Struct(`data`, &users.HoldCapture{}), middleware.Idempotent, users.Screened)
+	r.Invoke(`releaseHold`, users.ReleaseHold, middleware.Struct(`data`, &users.HoldID{}), middleware.Idempotent)
+
+	/***** escrow routes *****/
+
+	r.Invoke(`createEscrow`, users.CreateEscrow, middleware.Struct(`data`, &users.Escrow{}), middleware.Idempotent, users.Screened)
+	r.Query(`listEscrows`, users.ListEscrows, middleware.Struct(`data`, &users.UserId{}))
+	r.Invoke(`confirmEscrow`, users.ConfirmEscrow, middleware.Struct(`data`, &users.EscrowAction{}), middleware.Idempotent, users.Screened)
+	r.Invoke(`disputeEscrow`, users.DisputeEscrow, middleware.Struct(`data`, &users.EscrowAction{}), middleware.Idempotent)
+	r.Invoke(`resolveEscrow`, users.ResolveEscrow, middleware.Struct(`data`, &users.EscrowDecision{}), middleware.Idempotent, users.Screened)
+	r.Invoke(`refundEscrow`, users.RefundEscrow, middleware.Struct(`data`, &users.EscrowAction{}), middleware.Idempotent, users.Screened)
+	r.Query(`listExpiredEscrows`, users.ListExpiredEscrows)
+
+	/***** name routes *****/
+
+	r.Invoke(`registerName`, users.RegisterName, middleware.Struct(`data`, &users.NameRegistration{}), middleware.Idempotent)
+	r.Invoke(`renewName`, users.RenewName, middleware.Struct(`data`, &users.NameID{}), middleware.Idempotent)
+	r.Invoke(`transferName`, users.TransferName, middleware.Struct(`data`, &users.NameTransfer{}), middleware.Idempotent)
+	r.Query(`resolveName`, users.ResolveName, middleware.Struct(`data`, &users.NameQuery{}))
+	r.Query(`lookupName`, users.LookupName, middleware.Struct(`data`, &users.NameLookup{}))
+
+	/***** endorsement routes *****/
+
+	r.Invoke(`setKeyEndorsement`, users.SetKeyEndorsement, middleware.Struct(`data`, &users.KeyEndorsement{}), rbac.Only(utils.RoleAdmin), middleware.Idempotent)
+	r.Query(`getKeyEndorsement`, users.GetKeyEndorsement, middleware.Struct(`data`, &users.EndorsementKey{}))
+
+	/***** compliance routes *****/
+
+	r.Invoke(`addBlockedAddress`, users.AddBlockedAddress, middleware.Struct(`data`, &users.BlockedAddress{}), rbac.Only(utils.RoleCompliance), middleware.Idempotent)
+	r.Invoke(`importBlockedAddresses`, users.ImportBlockedAddresses, middleware.Struct(`data`, &users.BlockedAddresses{}), rbac.Only(utils.RoleCompliance), middleware.Idempotent)
+	r.Invoke(`removeBlockedAddress`, users.RemoveBlockedAddress, middleware.Struct(`data`, &users.BlockedAddressID{}), rbac.Only(utils.RoleCompliance), middleware.Idempotent)
+	r.Query(`listBlockedAddresses`, users.ListBlockedAddresses, rbac.Only(utils.RoleCompliance, utils.RoleAuditor, utils.RoleOperator))
+	r.Invoke(`setMonitoringRules`, users.SetMonitoringRules, middleware.Struct(`data`, &users.MonitoringRules{}), rbac.Only(utils.RoleCompliance), middleware.Idempotent)
+	r.Query(`getMonitoringRules`, users.GetMonitoringRules, middleware.Struct(`data`, &users.MonitoringRulesQuery{}), rbac.Only(utils.RoleCompliance, utils.RoleAuditor, utils.RoleOperator))
+	r.Query(`listAlerts`, users.ListAlerts, middleware.Struct(`data`, &users.AlertFilter{}), rbac.Only(utils.RoleAuditor))
+	r.Invoke(`resolveAlert`, users.ResolveAlert, middleware.Struct(`data`, &users.AlertResolution{}), rbac.Only(utils.RoleAuditor), middleware.Idempotent)
+
+	/***** access control routes *****/
+
+	r.Invoke(`grantRole`, rbac.GrantRole, middleware.Struct(`data`, &rbac.RoleRequest{}), rbac.Only(utils.RoleAdmin), middleware.Idempotent)
+	r.Invoke(`revokeRole`, rbac.RevokeRole, middleware.Struct(`data`, &rbac.RoleRequest{}), rbac.Only(utils.RoleAdmin), middleware.Idempotent)
+	r.Query(`listRoles`, rbac.ListRoles, rbac.Only(utils.RoleAdmin, utils.RoleAuditor))
+	r.Query(`whoAmI`, auth.WhoAmI)
+	r.Query(`listAdminAudit`, rbac.ListAdminAudit, rbac.Only(utils.RoleAdmin, utils.RoleAuditor))
+
+	/***** error routes *****/
+
+	r.Query(`getErrorCatalog`, status.GetErrorCatalog)
 
 	// return the routes
 	return chaincode
@@ -0,0 +1,227 @@
+// Package amount Money amounts with decimals and checked arithmetic. An amount is an integer
+// value of the smallest unit together with its scale, 12.50 is the value 1250 with the scale 2
+package amount
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+)
+
+// MaxScale the largest number of decimals of an amount
+const MaxScale = 18
+
+// scales the number of decimals by code, the other codes use utils.AssetScale
+var scales = map[string]int{
+	utils.WalletCoinSymbol: utils.WalletCoinScale,
+}
+
+// Amount an integer value with its scale
+type Amount struct {
+	value int64
+	scale int
+}
+
+// New returns the amount of the value in the smallest unit of the scale
+func New(value int64, scale int) Amount {
+	return Amount{value: value, scale: scale}
+}
+
+// Units returns the amount of whole units
+func Units(units int64) Amount {
+	return Amount{value: units}
+}
+
+// ScaleOf returns the number of decimals of the code
+func ScaleOf(code string) int {
+	if scale, ok := scales[code]; ok {
+		return scale
+	}
+	return utils.AssetScale
+}
+
+// Parse parses the decimal string, the scale is the number of decimals in the string
+func Parse(s string) (Amount, error) {
+	text := s
+	negative := strings.HasPrefix(text, "-")
+	if negative {
+		text = text[1:]
+	}
+	whole, fraction := text, ""
+	if i := strings.Index(text, "."); i >= 0 {
+		whole, fraction = text[:i], text[i+1:]
+	}
+	if whole == "" || len(fraction) > MaxScale || (fraction == "" && strings.HasSuffix(text, ".")) {
+		return Amount{}, status.ErrAmountInvalid.WithMessagef("Amount %s is not a decimal number!", s)
+	}
+
+	var value int64
+	for _, digit := range whole + fraction {
+		if digit < '0' || digit > '9' {
+			return Amount{}, status.ErrAmountInvalid.WithMessagef("Amount %s is not a decimal number!", s)
+		}
+		if value > (math.MaxInt64-int64(digit-'0'))/10 {
+			return Amount{}, status.ErrAmountOverflow.WithMessagef("Amount %s is too large!", s)
+		}
+		value = value*10 + int64(digit-'0')
+	}
+	if negative {
+		value = -value
+	}
+	return Amount{value: value, scale: len(fraction)}, nil
+}
+
+// Value returns the value in the smallest unit of the scale
+func (a Amount) Value() int64 {
+	return a.value
+}
+
+// Scale returns the number of decimals
+func (a Amount) Scale() int {
+	return a.scale
+}
+
+// Sign returns -1, 0 or 1 for a negative, zero or positive amount
+func (a Amount) Sign() int {
+	switch {
+	case a.value < 0:
+		return -1
+	case a.value > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero reports whether the amount is zero
+func (a Amount) IsZero() bool {
+	return a.value == 0
+}
+
+// Neg returns the negated amount
+func (a Amount) Neg() Amount {
+	return Amount{value: -a.value, scale: a.scale}
+}
+
+// Rescale returns the amount with the scale, the amounts which would lose decimals are rejected
+func (a Amount) Rescale(scale int) (Amount, error) {
+	if scale < 0 || scale > MaxScale {
+		return Amount{}, status.ErrAmountPrecision.WithMessagef("Amount %s can't have %d decimals!", a, scale)
+	}
+	value := a.value
+	for s := a.scale; s < scale; s++ {
+		if value > math.MaxInt64/10 || value < math.MinInt64/10 {
+			return Amount{}, status.ErrAmountOverflow.WithMessagef("Amount %s is too large!", a)
+		}
+		value = value * 10
+	}
+	for s := a.scale; s > scale; s-- {
+		if value%10 != 0 {
+			return Amount{}, status.ErrAmountPrecision.WithMessagef("Amount %s can't have more than %d decimals!", a, scale)
+		}
+		value = value / 10
+	}
+	return Amount{value: value, scale: scale}, nil
+}
+
+// For returns the amount with the scale of the code
+func (a Amount) For(code string) (Amount, error) {
+	return a.Rescale(ScaleOf(code))
+}
+
+// Add returns the sum of the amounts with the larger scale of both
+func (a Amount) Add(b Amount) (Amount, error) {
+	a, b, err := align(a, b)
+	if err != nil {
+		return Amount{}, err
+	}
+	sum := a.value + b.value
+	if (b.value > 0 && sum < a.value) || (b.value < 0 && sum > a.value) {
+		return Amount{}, status.ErrAmountOverflow.WithMessagef("Sum of %s and %s is too large!", a, b)
+	}
+	return Amount{value: sum, scale: a.scale}, nil
+}
+
+// Sub returns the difference of the amounts with the larger scale of both
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if b.value == math.MinInt64 {
+		return Amount{}, status.ErrAmountOverflow.WithMessagef("Amount %s is too large!", b)
+	}
+	return a.Add(b.Neg())
+}
+
+// Mul returns the amount multiplied by n
+func (a Amount) Mul(n int64) (Amount, error) {
+	product := a.value * n
+	if a.value != 0 && (product/a.value != n || (a.value == -1 && n == math.MinInt64)) {
+		return Amount{}, status.ErrAmountOverflow.WithMessagef("Product of %s and %d is too large!", a, n)
+	}
+	return Amount{value: product, scale: a.scale}, nil
+}
+
+// Cmp compares the amounts and returns -1, 0 or 1, the scales may differ
+func (a Amount) Cmp(b Amount) int {
+	return a.big(b.scale).Cmp(b.big(a.scale))
+}
+
+// String returns the amount as a decimal string
+func (a Amount) String() string {
+	digits := strconv.FormatInt(a.value, 10)
+	sign := ""
+	if a.value < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if a.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= a.scale {
+		digits = strings.Repeat("0", a.scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-a.scale] + "." + digits[len(digits)-a.scale:]
+}
+
+// MarshalJSON encodes the amount as a decimal string
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(a.String())), nil
+}
+
+// UnmarshalJSON decodes the amount from a decimal string or from the integer numbers which were
+// stored before the amounts had decimals
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// big returns the value of the amount at the larger of its scale and the scale
+func (a Amount) big(scale int) *big.Int {
+	value := big.NewInt(a.value)
+	if scale > a.scale {
+		value.Mul(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-a.scale)), nil))
+	}
+	return value
+}
+
+// align returns both amounts with the larger scale of the two
+func align(a Amount, b Amount) (Amount, Amount, error) {
+	var err error
+	if a.scale < b.scale {
+		a, err = a.Rescale(b.scale)
+	} else if b.scale < a.scale {
+		b, err = b.Rescale(a.scale)
+	}
+	return a, b, err
+}
@@ -0,0 +1,208 @@
+package amount
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+)
+
+// amountTest the result of an operation and the error status which it fails with, want is the
+// expected amount as a decimal string when err is nil
+type amountTest struct {
+	name   string
+	result Amount
+	resErr error
+	want   string
+	err    error
+}
+
+// runAmountTests checks the result or the error status of every operation
+func runAmountTests(t *testing.T, tests []amountTest) {
+	for _, test := range tests {
+		if test.err != nil {
+			if !errors.Is(test.resErr, test.err) {
+				t.Errorf("%s: got %v, want %v", test.name, test.resErr, test.err)
+			}
+			continue
+		}
+		if test.resErr != nil {
+			t.Errorf("%s: unexpected error %v", test.name, test.resErr)
+			continue
+		}
+		if got := test.result.String(); got != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+// mustParse parses the decimal amount of the test
+func mustParse(t *testing.T, s string) Amount {
+	a, err := Parse(s)
+	if err != nil {
+		t.Fatalf("parse %s: %v", s, err)
+	}
+	return a
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text  string
+		value int64
+		scale int
+		err   error
+	}{
+		{"0", 0, 0, nil},
+		{"12.50", 1250, 2, nil},
+		{"-0.5", -5, 1, nil},
+		{"0.000000000000000001", 1, MaxScale, nil},
+		{"9223372036854775807", math.MaxInt64, 0, nil},
+		{"9223372036854775808", 0, 0, status.ErrAmountOverflow},
+		{"0.0000000000000000001", 0, 0, status.ErrAmountInvalid},
+		{"", 0, 0, status.ErrAmountInvalid},
+		{"1.", 0, 0, status.ErrAmountInvalid},
+		{".5", 0, 0, status.ErrAmountInvalid},
+		{"1e3", 0, 0, status.ErrAmountInvalid},
+		{"+1", 0, 0, status.ErrAmountInvalid},
+	}
+	for _, test := range tests {
+		a, err := Parse(test.text)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got %v, want %v", test.text, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", test.text, err)
+			continue
+		}
+		if a.Value() != test.value || a.Scale() != test.scale {
+			t.Errorf("%q: got %d with scale %d, want %d with scale %d", test.text, a.Value(), a.Scale(), test.value, test.scale)
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	add := func(name string, a Amount, b Amount, want string, err error) amountTest {
+		result, resErr := a.Add(b)
+		return amountTest{name, result, resErr, want, err}
+	}
+	runAmountTests(t, []amountTest{
+		add("same scale", mustParse(t, "1.25"), mustParse(t, "2.50"), "3.75", nil),
+		add("larger scale kept", mustParse(t, "1.5"), mustParse(t, "0.25"), "1.75", nil),
+		add("negative", Units(1), Units(-3), "-2", nil),
+		add("to zero", mustParse(t, "0.5"), mustParse(t, "-0.50"), "0.00", nil),
+		add("maximum", New(math.MaxInt64-1, 0), Units(1), "9223372036854775807", nil),
+		add("overflow", New(math.MaxInt64, 0), Units(1), "", status.ErrAmountOverflow),
+		add("negative overflow", New(math.MinInt64, 0), Units(-1), "", status.ErrAmountOverflow),
+		add("overflow of the alignment", New(math.MaxInt64, 0), New(1, 1), "", status.ErrAmountOverflow),
+	})
+}
+
+func TestSub(t *testing.T) {
+	sub := func(name string, a Amount, b Amount, want string, err error) amountTest {
+		result, resErr := a.Sub(b)
+		return amountTest{name, result, resErr, want, err}
+	}
+	runAmountTests(t, []amountTest{
+		sub("same scale", mustParse(t, "3.75"), mustParse(t, "1.25"), "2.50", nil),
+		sub("below zero", Units(1), Units(3), "-2", nil),
+		sub("negative", Units(1), Units(-1), "2", nil),
+		sub("larger scale kept", Units(1), mustParse(t, "0.001"), "0.999", nil),
+		sub("overflow", New(math.MaxInt64, 0), Units(-1), "", status.ErrAmountOverflow),
+		sub("negative overflow", New(math.MinInt64, 0), Units(1), "", status.ErrAmountOverflow),
+		sub("minimum", Units(0), New(math.MinInt64, 0), "", status.ErrAmountOverflow),
+	})
+}
+
+func TestMul(t *testing.T) {
+	mul := func(name string, a Amount, n int64, want string, err error) amountTest {
+		result, resErr := a.Mul(n)
+		return amountTest{name, result, resErr, want, err}
+	}
+	runAmountTests(t, []amountTest{
+		mul("scale kept", mustParse(t, "1.25"), 3, "3.75", nil),
+		mul("by zero", mustParse(t, "1.25"), 0, "0.00", nil),
+		mul("zero", Units(0), math.MaxInt64, "0", nil),
+		mul("negative", mustParse(t, "0.5"), -3, "-1.5", nil),
+		mul("overflow", New(math.MaxInt64/2+1, 0), 2, "", status.ErrAmountOverflow),
+		mul("negative overflow", New(math.MaxInt64/2+1, 0), -3, "", status.ErrAmountOverflow),
+		mul("minimum by minus one", Units(-1), math.MinInt64, "", status.ErrAmountOverflow),
+		mul("minus one by minimum", New(math.MinInt64, 0), -1, "", status.ErrAmountOverflow),
+	})
+}
+
+func TestRescale(t *testing.T) {
+	rescale := func(name string, a Amount, scale int, want string, err error) amountTest {
+		result, resErr := a.Rescale(scale)
+		return amountTest{name, result, resErr, want, err}
+	}
+	runAmountTests(t, []amountTest{
+		rescale("up", mustParse(t, "1.5"), 3, "1.500", nil),
+		rescale("down without loss", mustParse(t, "1.500"), 1, "1.5", nil),
+		rescale("negative", mustParse(t, "-1.50"), 1, "-1.5", nil),
+		rescale("same scale", mustParse(t, "1.5"), 1, "1.5", nil),
+		rescale("not rounded", mustParse(t, "1.55"), 1, "", status.ErrAmountPrecision),
+		rescale("not truncated", mustParse(t, "0.001"), 0, "", status.ErrAmountPrecision),
+		rescale("negative scale", Units(1), -1, "", status.ErrAmountPrecision),
+		rescale("above maximum scale", Units(1), MaxScale+1, "", status.ErrAmountPrecision),
+		rescale("overflow", Units(10), MaxScale, "", status.ErrAmountOverflow),
+		rescale("negative overflow", Units(-10), MaxScale, "", status.ErrAmountOverflow),
+	})
+}
+
+func TestFor(t *testing.T) {
+	forCode := func(name string, a Amount, code string, want string, err error) amountTest {
+		result, resErr := a.For(code)
+		return amountTest{name, result, resErr, want, err}
+	}
+	runAmountTests(t, []amountTest{
+		forCode("wallet coins", Units(2), utils.WalletCoinSymbol, "2.00000000", nil),
+		forCode("wallet coins fraction", mustParse(t, "0.00000001"), utils.WalletCoinSymbol, "0.00000001", nil),
+		forCode("wallet coins too precise", mustParse(t, "0.000000001"), utils.WalletCoinSymbol, "", status.ErrAmountPrecision),
+		forCode("asset", mustParse(t, "3.0"), "GOLD", "3", nil),
+		forCode("asset fraction", mustParse(t, "3.5"), "GOLD", "", status.ErrAmountPrecision),
+	})
+}
+
+func TestCmp(t *testing.T) {
+	tests := []struct {
+		name string
+		a    Amount
+		b    Amount
+		want int
+	}{
+		{"equal across scales", mustParse(t, "1.50"), mustParse(t, "1.5"), 0},
+		{"less", mustParse(t, "1.49"), mustParse(t, "1.5"), -1},
+		{"greater", Units(2), mustParse(t, "1.99999999"), 1},
+		{"negative", Units(-1), Units(0), -1},
+		{"beyond int64 at the larger scale", New(math.MaxInt64, 0), New(1, MaxScale), 1},
+	}
+	for _, test := range tests {
+		if got := test.a.Cmp(test.b); got != test.want {
+			t.Errorf("%s: got %d, want %d", test.name, got, test.want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		a    Amount
+		want string
+	}{
+		{Units(12), "12"},
+		{New(1250, 2), "12.50"},
+		{New(5, 3), "0.005"},
+		{New(-5, 3), "-0.005"},
+		{New(0, 2), "0.00"},
+		{New(math.MinInt64, 0), "-9223372036854775808"},
+	}
+	for _, test := range tests {
+		if got := test.a.String(); got != test.want {
+			t.Errorf("%d with scale %d: got %s, want %s", test.a.Value(), test.a.Scale(), got, test.want)
+		}
+	}
+}
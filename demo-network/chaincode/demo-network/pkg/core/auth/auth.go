@@ -0,0 +1,130 @@
+// Package auth Attribute based access control from the attributes which the Fabric CA embeds
+// in the certificate of the invoker
+package auth
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/hyperledger/fabric/common/attrmgr"
+	"github.com/s7techlab/cckit/router"
+)
+
+// Claims the identity and the certificate attributes of the invoker
+type Claims struct {
+	MSPID        string            `json:"msp_id"`
+	EnrollmentID string            `json:"enrollment_id"`
+	Attributes   map[string]string `json:"attributes"`
+}
+
+// Value returns the value of the attribute and whether the invoker has it
+func (claims Claims) Value(attribute string) (string, bool) {
+	value, found := claims.Attributes[attribute]
+	return value, found
+}
+
+// Rule an attribute based access rule on the claims of the invoker
+type Rule struct {
+	Attribute   string
+	Description string
+	Match       func(value string) bool
+}
+
+// Equals requires the attribute to have the value
+func Equals(attribute string, value string) Rule {
+	return Rule{
+		Attribute:   attribute,
+		Description: fmt.Sprintf("%s=%s", attribute, value),
+		Match:       func(actual string) bool { return actual == value },
+	}
+}
+
+// OneOf requires the attribute to have any of the values
+func OneOf(attribute string, values ...string) Rule {
+	return Rule{
+		Attribute:   attribute,
+		Description: fmt.Sprintf("%s in %v", attribute, values),
+		Match: func(actual string) bool {
+			for _, value := range values {
+				if actual == value {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// AtLeast requires the numeric attribute to be greater or equal to the level
+func AtLeast(attribute string, level int) Rule {
+	return Rule{
+		Attribute:   attribute,
+		Description: fmt.Sprintf("%s>=%d", attribute, level),
+		Match: func(actual string) bool {
+			value, err := strconv.Atoi(actual)
+			return err == nil && value >= level
+		},
+	}
+}
+
+// Require allows the route only to the invokers whose claims match all the rules
+func Require(rules ...Rule) router.MiddlewareFunc {
+	return func(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+		return func(c router.Context) (interface{}, error) {
+			claims, err := FromContext(c)
+			if err != nil {
+				return nil, err
+			}
+			for _, rule := range rules {
+				value, found := claims.Value(rule.Attribute)
+				if !found || !rule.Match(value) {
+					return nil, status.ErrForbidden.WithMessagef("This action requires %s!", rule.Description)
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// FromContext returns the claims of the invoker, they are read from the certificate once per invocation
+func FromContext(c router.Context) (Claims, error) {
+	if claims, ok := c.Get(utils.ClaimsKey).(Claims); ok {
+		return claims, nil
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		return Claims{}, status.ErrInternal.WithError(err)
+	}
+	mspID, err := client.GetMSPID()
+	if err != nil {
+		return Claims{}, status.ErrInternal.WithError(err)
+	}
+	cert, err := client.GetX509Certificate()
+	if err != nil {
+		return Claims{}, status.ErrInternal.WithError(err)
+	}
+	attributes, err := attrmgr.New().GetAttributesFromCert(cert)
+	if err != nil {
+		return Claims{}, status.ErrInternal.WithError(err)
+	}
+
+	claims := Claims{MSPID: mspID, EnrollmentID: cert.Subject.CommonName, Attributes: map[string]string{}}
+	for name, value := range attributes.Attrs {
+		claims.Attributes[name] = value
+	}
+	if enrollmentID, found := claims.Value(utils.AttrEnrollmentID); found {
+		claims.EnrollmentID = enrollmentID
+	}
+
+	c.Set(utils.ClaimsKey, claims)
+	return claims, nil
+}
+
+// WhoAmI get the claims of the invoker
+func WhoAmI(c router.Context) (interface{}, error) {
+	return FromContext(c)
+}
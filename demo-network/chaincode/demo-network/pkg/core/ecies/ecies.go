@@ -0,0 +1,27 @@
+// Package ecies The ECDSA public keys of the Fabric BCCSP which the users register for their private
+// memos. The clients encrypt the memos to these keys, the chaincode only checks the keys and stores
+// the ciphertexts
+package ecies
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/hyperledger/fabric/bccsp/utils"
+)
+
+// ErrInvalidKey the key is not an ECDSA key
+var ErrInvalidKey = errors.New("ecies: the key is not an ECDSA key")
+
+// ParsePublicKey parses the PEM encoded ECDSA public key
+func ParsePublicKey(raw []byte) (*ecdsa.PublicKey, error) {
+	key, err := utils.PEMtoPublicKey(raw, nil)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	publicKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	return publicKey, nil
+}
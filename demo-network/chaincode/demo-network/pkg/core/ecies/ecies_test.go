@@ -0,0 +1,41 @@
+package ecies
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp/utils"
+)
+
+// newKey generates a private key on the curve
+func newKey(t *testing.T, curve elliptic.Curve) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return key
+}
+
+func TestParsePublicKey(t *testing.T) {
+	key := newKey(t, elliptic.P256())
+	raw, err := utils.PublicKeyToPEM(&key.PublicKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := ParsePublicKey(raw)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if publicKey.X.Cmp(key.X) != 0 || publicKey.Y.Cmp(key.Y) != 0 {
+		t.Error("parsed key differs from the encoded key")
+	}
+
+	for _, raw := range [][]byte{nil, []byte("not a key"), raw[:len(raw)/2]} {
+		_, err := ParsePublicKey(raw)
+		if err != ErrInvalidKey {
+			t.Errorf("parse %q: got %v, want %v", raw, err, ErrInvalidKey)
+		}
+	}
+}
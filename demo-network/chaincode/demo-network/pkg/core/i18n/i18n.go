@@ -0,0 +1,72 @@
+// Package i18n Message catalogs for the validation and status messages. The catalogs are keyed by
+// the English message or format and are compiled into the chaincode, one file per locale
+package i18n
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// catalogs the translations by locale
+var catalogs = map[string]map[string]string{}
+
+// register adds the translations of the locale, it is called by the catalog files
+func register(locale string, messages map[string]string) {
+	catalogs[locale] = messages
+}
+
+// Translate returns the message in the locale, the message itself is returned when there is no translation
+func Translate(locale string, message string) string {
+	if translated, ok := catalogs[locale][message]; ok {
+		return translated
+	}
+	return message
+}
+
+// Locale returns the locale of the invocation. It is taken from the locale entry of the transient
+// map or the locale field of the payload and falls back to the default locale
+func Locale(c router.Context) string {
+	if locale, ok := c.Get(utils.LocaleKey).(string); ok {
+		return locale
+	}
+
+	locale := ""
+	transient, err := c.Stub().GetTransient()
+	if err == nil && transient[utils.TransientLocaleKey] != nil {
+		_ = json.Unmarshal(transient[utils.TransientLocaleKey], &locale)
+	}
+	if locale == "" {
+		args := c.Stub().GetArgs()
+		for i := 1; i < len(args); i++ {
+			payload := struct {
+				Locale string `json:"locale"`
+			}{}
+			if json.Unmarshal(args[i], &payload) == nil && payload.Locale != "" {
+				locale = payload.Locale
+				break
+			}
+		}
+	}
+
+	locale = match(locale)
+	c.Set(utils.LocaleKey, locale)
+	return locale
+}
+
+// match returns the supported locale for the requested one, es-MX is served by es
+func match(requested string) string {
+	requested = strings.ToLower(strings.Replace(requested, "_", "-", -1))
+	if _, ok := catalogs[requested]; ok {
+		return requested
+	}
+	if i := strings.Index(requested, "-"); i > 0 {
+		if _, ok := catalogs[requested[:i]]; ok {
+			return requested[:i]
+		}
+	}
+	return utils.DefaultLocale
+}
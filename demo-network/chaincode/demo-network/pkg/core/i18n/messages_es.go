@@ -0,0 +1,201 @@
+// Package i18n Spanish catalog
+package i18n
+
+import "github.com/chaincode/demo-network/pkg/core/utils"
+
+func init() {
+	register("es", map[string]string{
+		// validation messages
+		utils.IDRequired:           "El ID es obligatorio.",
+		utils.UserIDRequired:       "El ID de usuario es obligatorio.",
+		utils.CodeRequired:         "Por favor, introduzca el código.",
+		utils.LabelRequired:        "Por favor, introduzca la etiqueta.",
+		utils.QuantityRequired:     "Por favor, introduzca la cantidad.",
+		utils.NameRequired:         "Por favor, introduzca el nombre.",
+		utils.EmailRequired:        "Por favor, introduzca el correo electrónico.",
+		utils.PhoneRequired:        "Por favor, introduzca el teléfono.",
+		utils.AddressRequired:      "Por favor, introduzca la dirección.",
+		utils.SecretRequired:       "La clave secreta es obligatoria.",
+		utils.SecretInvalid:        "La clave secreta no es válida.",
+		utils.IdentityRequired:     "La identidad es obligatoria.",
+		utils.ReasonRequired:       "Por favor, introduzca el motivo.",
+		utils.AddressesEmpty:       "Por favor, introduzca al menos una dirección.",
+		utils.AlertIDRequired:      "El ID de la alerta es obligatorio.",
+		utils.ResolutionRequired:   "Por favor, introduzca la resolución.",
+		utils.FormatRequired:       "Por favor, introduzca el formato.",
+		utils.FormatInvalid:        "El formato debe ser csv o json.",
+		utils.DataRequired:         "Por favor, introduzca los datos.",
+		utils.PositiveRequired:     "El valor debe ser mayor que cero.",
+		utils.CollectionRequired:   "Por favor, introduzca la colección.",
+		utils.ReferenceRequired:    "La referencia es obligatoria.",
+		utils.SaltInvalid:          "La sal debe tener al menos 16 caracteres.",
+		utils.KeyRequired:          "La clave es obligatoria.",
+		utils.OrgsRequired:         "Por favor, introduzca al menos una organización.",
+		utils.MSPIDRequired:        "El ID de MSP es obligatorio.",
+		utils.UserRequired:         "El usuario es obligatorio.",
+		utils.RoleRequired:         "Por favor, introduzca el rol.",
+		utils.RoleInvalid:          "El rol debe ser admin, issuer, compliance, auditor u operator.",
+		utils.AmountTooLarge:       "El valor no debe ser mayor que 1000000000.",
+		utils.CodeInvalid:          "El código debe tener de 2 a 10 letras mayúsculas o dígitos y empezar por una letra.",
+		utils.LabelInvalid:         "La etiqueta debe tener como máximo 50 letras, dígitos, espacios o caracteres . _ ' -.",
+		utils.AddressInvalid:       "La dirección debe tener de 26 a 64 letras o dígitos.",
+		utils.MemoInvalid:          "El concepto debe tener como máximo 140 caracteres.",
+		utils.MemoRequired:         "Por favor, introduzca el concepto.",
+		utils.PublicKeyRequired:    "La clave pública es obligatoria.",
+		utils.PaymentIDRequired:    "El ID de la solicitud de pago es obligatorio.",
+		utils.ExpiryInvalid:        "El vencimiento debe ser una fecha RFC3339.",
+		utils.StatusInvalid:        "El estado debe ser pending, partially_paid, settled, declined o expired.",
+		utils.TransferIDRequired:   "El ID de la transferencia es obligatorio.",
+		utils.HoldIDRequired:       "El ID de la retención es obligatorio.",
+		utils.EscrowIDRequired:     "El ID del depósito en garantía es obligatorio.",
+		utils.AddressNameInvalid:   "El nombre debe tener partes separadas por puntos de letras minúsculas, dígitos o -, como alice.wallet.",
+		utils.RecipientInvalid:     "El destinatario debe ser una dirección o un nombre registrado.",
+		utils.EncryptedMemoInvalid: "El concepto cifrado debe ser un texto cifrado en base64 de como máximo 1024 caracteres.",
+
+		// status messages
+		"Internal Server Error":  "Error interno del servidor",
+		"Not Found":              "No encontrado",
+		"Bad Request":            "Solicitud incorrecta",
+		"Unauthorized":           "No autorizado",
+		"Forbidden":              "Prohibido",
+		"Not Implemented":        "No implementado",
+		"Unsupported Media Type": "Tipo de medio no admitido",
+		"Conflict because of inconsistent or duplicated info":   "Conflicto por información incoherente o duplicada",
+		"The entered data is invalid.":                          "Los datos introducidos no son válidos.",
+		"You don't have enough coins":                           "No tiene suficientes monedas",
+		"You don't have enough quantity of the asset":           "No tiene suficiente cantidad del activo",
+		"You can't transfer to yourself":                        "No puede transferirse a sí mismo",
+		"Please select two different addresses":                 "Por favor, seleccione dos direcciones diferentes",
+		"Address has been retired":                              "La dirección ha sido retirada",
+		"Primary address can't be retired":                      "La dirección principal no se puede retirar",
+		"Symbol already exists":                                 "El símbolo ya existe",
+		"Name already exists":                                   "El nombre ya existe",
+		"This label has already been taken":                     "Esta etiqueta ya está en uso",
+		"This address already exists in the system":             "Esta dirección ya existe en el sistema",
+		"Address already exists in your address book":           "La dirección ya existe en su libreta de direcciones",
+		"Alert is already resolved":                             "La alerta ya está resuelta",
+		"User already has the role":                             "El usuario ya tiene el rol",
+		"User does not exist":                                   "El usuario no existe",
+		"User does not exist in this system":                    "El usuario no existe en este sistema",
+		"Address does not belong to you":                        "La dirección no le pertenece",
+		"Address is not blocked":                                "La dirección no está bloqueada",
+		"Label does not exist":                                  "La etiqueta no existe",
+		"Alert does not exist":                                  "La alerta no existe",
+		"Transfer does not exist":                               "La transferencia no existe",
+		"User does not have the role":                           "El usuario no tiene el rol",
+		"Amount is not a decimal number":                        "La cantidad no es un número decimal",
+		"Amount is too large":                                   "La cantidad es demasiado grande",
+		"Amount has too many decimals":                          "La cantidad tiene demasiados decimales",
+		"Journal entry is not balanced":                         "El asiento contable no está equilibrado",
+		"Request ID has already been used with another payload": "El ID de solicitud ya se ha utilizado con otros datos",
+		"Receiver has not registered a memo key":                "El destinatario no ha registrado una clave de concepto",
+		"Payment request does not exist":                        "La solicitud de pago no existe",
+		"Payment request is no longer open":                     "La solicitud de pago ya no está abierta",
+		"Transfer is no longer pending":                         "La transferencia ya no está pendiente",
+		"Receiver is not on the allowlist of the asset":         "El destinatario no está en la lista de permitidos del activo",
+		"Hold does not exist":                                   "La retención no existe",
+		"Hold is no longer active":                              "La retención ya no está activa",
+		"Escrow does not exist":                                 "El depósito en garantía no existe",
+		"Escrow can't be changed in its current status":         "El depósito en garantía no se puede cambiar en su estado actual",
+		"Name is already registered":                            "El nombre ya está registrado",
+		"Name is not registered":                                "El nombre no está registrado",
+		"Address is on the blocked list":                        "La dirección está en la lista de bloqueo",
+		"Transient data is required":                            "Los datos transitorios son obligatorios",
+
+		// error messages
+		"Amount %s is not a decimal number!":                                          "¡La cantidad %s no es un número decimal!",
+		"Amount %s is too large!":                                                     "¡La cantidad %s es demasiado grande!",
+		"Amount %s can't have %d decimals!":                                           "¡La cantidad %s no puede tener %d decimales!",
+		"Amount %s can't have more than %d decimals!":                                 "¡La cantidad %s no puede tener más de %d decimales!",
+		"Sum of %s and %s is too large!":                                              "¡La suma de %s y %s es demasiado grande!",
+		"Product of %s and %d is too large!":                                          "¡El producto de %s y %d es demasiado grande!",
+		"Address %s already exists in your address book!":                             "¡La dirección %s ya existe en su libreta de direcciones!",
+		"Address %s already has the name %s!":                                         "¡La dirección %s ya tiene el nombre %s!",
+		"Address %s does not belong to you!":                                          "¡La dirección %s no le pertenece!",
+		"Address %s does not exist in your address book!":                             "¡La dirección %s no existe en su libreta de direcciones!",
+		"Address %s has been retired!":                                                "¡La dirección %s ha sido retirada!",
+		"Address %s has no name!":                                                     "¡La dirección %s no tiene nombre!",
+		"Address %s is already retired!":                                              "¡La dirección %s ya está retirada!",
+		"Address %s is not blocked!":                                                  "¡La dirección %s no está bloqueada!",
+		"Address %s is on the blocked list!":                                          "¡La dirección %s está en la lista de bloqueo!",
+		"Address already exists with the given address %s!":                           "¡Ya existe una dirección con la dirección %s!",
+		"Alert %s does not exist!":                                                    "¡La alerta %s no existe!",
+		"Alert %s is already resolved!":                                               "¡La alerta %s ya está resuelta!",
+		"Collection %s is not shared with the organization %s!":                       "¡La colección %s no se comparte con la organización %s!",
+		"Escrow %s can't be refunded before %s!":                                      "¡El depósito en garantía %s no se puede reembolsar antes de %s!",
+		"Escrow %s does not exist!":                                                   "¡El depósito en garantía %s no existe!",
+		"Escrow %s has no arbiter!":                                                   "¡El depósito en garantía %s no tiene árbitro!",
+		"Escrow %s is %s!":                                                            "¡El depósito en garantía %s está en estado %s!",
+		"Expiry %s is not in the future!":                                             "¡El vencimiento %s no está en el futuro!",
+		"Field %s is not allowed!":                                                    "¡El campo %s no está permitido!",
+		"Document %s does not exist!":                                                 "¡El documento %s no existe!",
+		"Hold %s does not exist!":                                                     "¡La retención %s no existe!",
+		"Hold %s is %s!":                                                              "¡La retención %s está en estado %s!",
+		"Hold %s is not placed for you!":                                              "¡La retención %s no está a su favor!",
+		"Journal entry %s is not balanced for %s!":                                    "¡El asiento contable %s no está equilibrado en %s!",
+		"Label %s does not exist in your address book!":                               "¡La etiqueta %s no existe en su libreta de direcciones!",
+		"Label does not exist for this address.":                                      "La etiqueta no existe para esta dirección.",
+		"Label of receiver does not exist!":                                           "¡La etiqueta del destinatario no existe!",
+		"Label of sender does not exist!":                                             "¡La etiqueta del remitente no existe!",
+		"Line %d should have address and label!":                                      "¡La línea %d debe tener dirección y etiqueta!",
+		"Merchant %s does not exist!":                                                 "¡El comercio %s no existe!",
+		"Name %s already exists!":                                                     "¡El nombre %s ya existe!",
+		"Name %s already points to %s!":                                               "¡El nombre %s ya apunta a %s!",
+		"Name %s has expired!":                                                        "¡El nombre %s ha vencido!",
+		"Name %s is already registered!":                                              "¡El nombre %s ya está registrado!",
+		"Name %s is not registered by you!":                                           "¡El nombre %s no está registrado por usted!",
+		"Name %s is not registered!":                                                  "¡El nombre %s no está registrado!",
+		"Only the arbiter of escrow %s can decide its dispute!":                       "¡Solo el árbitro del depósito en garantía %s puede resolver su disputa!",
+		"Only the issuer of %s can manage its allowlist!":                             "¡Solo el emisor de %s puede gestionar su lista de permitidos!",
+		"Only the issuer of %s can restrict its transfers!":                           "¡Solo el emisor de %s puede restringir sus transferencias!",
+		"Only the issuer or the transfer agent of %s can review its transfers!":       "¡Solo el emisor o el agente de transferencias de %s puede revisar sus transferencias!",
+		"Payer %s does not exist!":                                                    "¡El pagador %s no existe!",
+		"Payment request %s does not exist!":                                          "¡La solicitud de pago %s no existe!",
+		"Payment request %s is %s!":                                                   "¡La solicitud de pago %s está en estado %s!",
+		"Payment request %s is not addressed to you!":                                 "¡La solicitud de pago %s no está dirigida a usted!",
+		"Payment request %s waits for the approval of the transfer %s!":               "¡La solicitud de pago %s espera la aprobación de la transferencia %s!",
+		"Please select two different addresses!":                                      "¡Por favor, seleccione dos direcciones diferentes!",
+		"Primary address can't be retired, please set another primary address first!": "La dirección principal no se puede retirar, ¡primero establezca otra dirección principal!",
+		"Private data %s does not exist!":                                             "¡Los datos privados %s no existen!",
+		"Public key of user %s is not a valid ECDSA key!":                             "¡La clave pública del usuario %s no es una clave ECDSA válida!",
+		"Quantity should be less or equal to %s":                                      "La cantidad debe ser menor o igual a %s",
+		"Receiver %s has not registered a memo key!":                                  "¡El destinatario %s no ha registrado una clave de concepto!",
+		"Receiver %s does not exist!":                                                 "¡El destinatario %s no existe!",
+		"Receiver %s is not on the allowlist of %s!":                                  "¡El destinatario %s no está en la lista de permitidos de %s!",
+		"Record does not exist in your address book.":                                 "El registro no existe en su libreta de direcciones.",
+		"Request %s has already been used with another payload!":                      "¡La solicitud %s ya se ha utilizado con otros datos!",
+		"Restricted asset %s can't be escrowed!":                                      "¡El activo restringido %s no se puede depositar en garantía!",
+		"Seller %s does not exist!":                                                   "¡El vendedor %s no existe!",
+		"Symbol %s already exists!":                                                   "¡El símbolo %s ya existe!",
+		"Symbol %s does not exist!":                                                   "¡El símbolo %s no existe!",
+		"The arbiter can't be a party of the escrow!":                                 "¡El árbitro no puede ser parte del depósito en garantía!",
+		"This action requires %s!":                                                    "¡Esta acción requiere %s!",
+		"This action requires the %s role!":                                           "¡Esta acción requiere el rol %s!",
+		"This address %s already exists in the system!":                               "¡La dirección %s ya existe en el sistema!",
+		"This label %s has already been taken!":                                       "¡La etiqueta %s ya está en uso!",
+		"This label already exists!":                                                  "¡Esta etiqueta ya existe!",
+		"Transfer %s can't be refunded before %s!":                                    "¡La transferencia %s no se puede reembolsar antes de %s!",
+		"Transfer %s does not exist!":                                                 "¡La transferencia %s no existe!",
+		"Transfer %s has expired!":                                                    "¡La transferencia %s ha vencido!",
+		"Transfer %s is already %s!":                                                  "¡La transferencia %s ya está en estado %s!",
+		"Transfer %s is not addressed to you!":                                        "¡La transferencia %s no está dirigida a usted!",
+		"Transfer %s was not sent by you!":                                            "¡La transferencia %s no ha sido enviada por usted!",
+		"Transient data %s is required!":                                              "¡Los datos transitorios %s son obligatorios!",
+		"Unexpected error, correlation ID %s":                                         "Error inesperado, ID de correlación %s",
+		"User %s already has the %s role!":                                            "¡El usuario %s ya tiene el rol %s!",
+		"User %s does not exist!":                                                     "¡El usuario %s no existe!",
+		"User %s does not have the %s role!":                                          "¡El usuario %s no tiene el rol %s!",
+		"User already exists with the given address %s!":                              "¡Ya existe un usuario con la dirección %s!",
+		"User does not exist in this system!":                                         "¡El usuario no existe en este sistema!",
+		"You account %s does not exist!":                                              "¡Su cuenta %s no existe!",
+		"You are not a party of escrow %s!":                                           "¡No es parte del depósito en garantía %s!",
+		"You can't act as user %s!":                                                   "¡No puede actuar como el usuario %s!",
+		"You can't open an escrow with yourself!":                                     "¡No puede abrirse un depósito en garantía consigo mismo!",
+		"You can't place a hold for yourself!":                                        "¡No puede hacerse una retención a sí mismo!",
+		"You can't request a payment from yourself!":                                  "¡No puede solicitarse un pago a sí mismo!",
+		"You can't transfer asset to yourself!":                                       "¡No puede transferirse un activo a sí mismo!",
+		"You can't transfer coins to yourself!":                                       "¡No puede transferirse monedas a sí mismo!",
+		"You don't have enough coins to purchase this asset.":                         "No tiene suficientes monedas para comprar este activo.",
+		"You don't have enough coins to transfer the asset.":                          "No tiene suficientes monedas para transferir el activo.",
+	})
+}
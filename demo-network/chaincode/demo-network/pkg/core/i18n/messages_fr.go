@@ -0,0 +1,201 @@
+// Package i18n French catalog
+package i18n
+
+import "github.com/chaincode/demo-network/pkg/core/utils"
+
+func init() {
+	register("fr", map[string]string{
+		// validation messages
+		utils.IDRequired:           "L'ID est obligatoire.",
+		utils.UserIDRequired:       "L'ID utilisateur est obligatoire.",
+		utils.CodeRequired:         "Veuillez saisir le code.",
+		utils.LabelRequired:        "Veuillez saisir le libellé.",
+		utils.QuantityRequired:     "Veuillez saisir la quantité.",
+		utils.NameRequired:         "Veuillez saisir le nom.",
+		utils.EmailRequired:        "Veuillez saisir l'e-mail.",
+		utils.PhoneRequired:        "Veuillez saisir le téléphone.",
+		utils.AddressRequired:      "Veuillez saisir l'adresse.",
+		utils.SecretRequired:       "La clé secrète est obligatoire.",
+		utils.SecretInvalid:        "La clé secrète n'est pas valide.",
+		utils.IdentityRequired:     "L'identité est obligatoire.",
+		utils.ReasonRequired:       "Veuillez saisir le motif.",
+		utils.AddressesEmpty:       "Veuillez saisir au moins une adresse.",
+		utils.AlertIDRequired:      "L'ID de l'alerte est obligatoire.",
+		utils.ResolutionRequired:   "Veuillez saisir la résolution.",
+		utils.FormatRequired:       "Veuillez saisir le format.",
+		utils.FormatInvalid:        "Le format doit être csv ou json.",
+		utils.DataRequired:         "Veuillez saisir les données.",
+		utils.PositiveRequired:     "La valeur doit être supérieure à zéro.",
+		utils.CollectionRequired:   "Veuillez saisir la collection.",
+		utils.ReferenceRequired:    "La référence est obligatoire.",
+		utils.SaltInvalid:          "Le sel doit contenir au moins 16 caractères.",
+		utils.KeyRequired:          "La clé est obligatoire.",
+		utils.OrgsRequired:         "Veuillez saisir au moins une organisation.",
+		utils.MSPIDRequired:        "L'ID MSP est obligatoire.",
+		utils.UserRequired:         "L'utilisateur est obligatoire.",
+		utils.RoleRequired:         "Veuillez saisir le rôle.",
+		utils.RoleInvalid:          "Le rôle doit être admin, issuer, compliance, auditor ou operator.",
+		utils.AmountTooLarge:       "La valeur ne doit pas être supérieure à 1000000000.",
+		utils.CodeInvalid:          "Le code doit contenir de 2 à 10 lettres majuscules ou chiffres et commencer par une lettre.",
+		utils.LabelInvalid:         "Le libellé doit contenir au plus 50 lettres, chiffres, espaces ou caractères . _ ' -.",
+		utils.AddressInvalid:       "L'adresse doit contenir de 26 à 64 lettres ou chiffres.",
+		utils.MemoInvalid:          "Le libellé du paiement doit contenir au plus 140 caractères.",
+		utils.MemoRequired:         "Veuillez saisir le libellé du paiement.",
+		utils.PublicKeyRequired:    "La clé publique est obligatoire.",
+		utils.PaymentIDRequired:    "L'ID de la demande de paiement est obligatoire.",
+		utils.ExpiryInvalid:        "L'échéance doit être une date RFC3339.",
+		utils.StatusInvalid:        "Le statut doit être pending, partially_paid, settled, declined ou expired.",
+		utils.TransferIDRequired:   "L'ID du transfert est obligatoire.",
+		utils.HoldIDRequired:       "L'ID de la réservation est obligatoire.",
+		utils.EscrowIDRequired:     "L'ID du séquestre est obligatoire.",
+		utils.AddressNameInvalid:   "Le nom doit être composé de parties séparées par des points, en lettres minuscules, chiffres ou -, comme alice.wallet.",
+		utils.RecipientInvalid:     "Le destinataire doit être une adresse ou un nom enregistré.",
+		utils.EncryptedMemoInvalid: "Le libellé chiffré doit être un texte chiffré en base64 d'au plus 1024 caractères.",
+
+		// status messages
+		"Internal Server Error":  "Erreur interne du serveur",
+		"Not Found":              "Introuvable",
+		"Bad Request":            "Requête incorrecte",
+		"Unauthorized":           "Non autorisé",
+		"Forbidden":              "Interdit",
+		"Not Implemented":        "Non implémenté",
+		"Unsupported Media Type": "Type de média non pris en charge",
+		"Conflict because of inconsistent or duplicated info":   "Conflit dû à des informations incohérentes ou en double",
+		"The entered data is invalid.":                          "Les données saisies ne sont pas valides.",
+		"You don't have enough coins":                           "Vous n'avez pas assez de pièces",
+		"You don't have enough quantity of the asset":           "Vous n'avez pas assez de quantité de l'actif",
+		"You can't transfer to yourself":                        "Vous ne pouvez pas vous transférer à vous-même",
+		"Please select two different addresses":                 "Veuillez sélectionner deux adresses différentes",
+		"Address has been retired":                              "L'adresse a été retirée",
+		"Primary address can't be retired":                      "L'adresse principale ne peut pas être retirée",
+		"Symbol already exists":                                 "Le symbole existe déjà",
+		"Name already exists":                                   "Le nom existe déjà",
+		"This label has already been taken":                     "Ce libellé est déjà utilisé",
+		"This address already exists in the system":             "Cette adresse existe déjà dans le système",
+		"Address already exists in your address book":           "L'adresse existe déjà dans votre carnet d'adresses",
+		"Alert is already resolved":                             "L'alerte est déjà résolue",
+		"User already has the role":                             "L'utilisateur a déjà le rôle",
+		"User does not exist":                                   "L'utilisateur n'existe pas",
+		"User does not exist in this system":                    "L'utilisateur n'existe pas dans ce système",
+		"Address does not belong to you":                        "L'adresse ne vous appartient pas",
+		"Address is not blocked":                                "L'adresse n'est pas bloquée",
+		"Label does not exist":                                  "Le libellé n'existe pas",
+		"Alert does not exist":                                  "L'alerte n'existe pas",
+		"Transfer does not exist":                               "Le transfert n'existe pas",
+		"User does not have the role":                           "L'utilisateur n'a pas le rôle",
+		"Amount is not a decimal number":                        "Le montant n'est pas un nombre décimal",
+		"Amount is too large":                                   "Le montant est trop grand",
+		"Amount has too many decimals":                          "Le montant a trop de décimales",
+		"Journal entry is not balanced":                         "L'écriture comptable n'est pas équilibrée",
+		"Request ID has already been used with another payload": "L'ID de requête a déjà été utilisé avec d'autres données",
+		"Receiver has not registered a memo key":                "Le destinataire n'a pas enregistré de clé de libellé",
+		"Payment request does not exist":                        "La demande de paiement n'existe pas",
+		"Payment request is no longer open":                     "La demande de paiement n'est plus ouverte",
+		"Transfer is no longer pending":                         "Le transfert n'est plus en attente",
+		"Receiver is not on the allowlist of the asset":         "Le destinataire ne figure pas sur la liste d'autorisation de l'actif",
+		"Hold does not exist":                                   "La réservation n'existe pas",
+		"Hold is no longer active":                              "La réservation n'est plus active",
+		"Escrow does not exist":                                 "Le séquestre n'existe pas",
+		"Escrow can't be changed in its current status":         "Le séquestre ne peut pas être modifié dans son statut actuel",
+		"Name is already registered":                            "Le nom est déjà enregistré",
+		"Name is not registered":                                "Le nom n'est pas enregistré",
+		"Address is on the blocked list":                        "L'adresse est sur la liste de blocage",
+		"Transient data is required":                            "Les données transitoires sont obligatoires",
+
+		// error messages
+		"Amount %s is not a decimal number!":                                          "Le montant %s n'est pas un nombre décimal !",
+		"Amount %s is too large!":                                                     "Le montant %s est trop grand !",
+		"Amount %s can't have %d decimals!":                                           "Le montant %s ne peut pas avoir %d décimales !",
+		"Amount %s can't have more than %d decimals!":                                 "Le montant %s ne peut pas avoir plus de %d décimales !",
+		"Sum of %s and %s is too large!":                                              "La somme de %s et %s est trop grande !",
+		"Product of %s and %d is too large!":                                          "Le produit de %s et %d est trop grand !",
+		"Address %s already exists in your address book!":                             "L'adresse %s existe déjà dans votre carnet d'adresses !",
+		"Address %s already has the name %s!":                                         "L'adresse %s a déjà le nom %s !",
+		"Address %s does not belong to you!":                                          "L'adresse %s ne vous appartient pas !",
+		"Address %s does not exist in your address book!":                             "L'adresse %s n'existe pas dans votre carnet d'adresses !",
+		"Address %s has been retired!":                                                "L'adresse %s a été retirée !",
+		"Address %s has no name!":                                                     "L'adresse %s n'a pas de nom !",
+		"Address %s is already retired!":                                              "L'adresse %s est déjà retirée !",
+		"Address %s is not blocked!":                                                  "L'adresse %s n'est pas bloquée !",
+		"Address %s is on the blocked list!":                                          "L'adresse %s est sur la liste de blocage !",
+		"Address already exists with the given address %s!":                           "Une adresse existe déjà avec l'adresse %s !",
+		"Alert %s does not exist!":                                                    "L'alerte %s n'existe pas !",
+		"Alert %s is already resolved!":                                               "L'alerte %s est déjà résolue !",
+		"Collection %s is not shared with the organization %s!":                       "La collection %s n'est pas partagée avec l'organisation %s !",
+		"Escrow %s can't be refunded before %s!":                                      "Le séquestre %s ne peut pas être remboursé avant %s !",
+		"Escrow %s does not exist!":                                                   "Le séquestre %s n'existe pas !",
+		"Escrow %s has no arbiter!":                                                   "Le séquestre %s n'a pas d'arbitre !",
+		"Escrow %s is %s!":                                                            "Le séquestre %s est à l'état %s !",
+		"Expiry %s is not in the future!":                                             "L'échéance %s n'est pas dans le futur !",
+		"Field %s is not allowed!":                                                    "Le champ %s n'est pas autorisé !",
+		"Document %s does not exist!":                                                 "Le document %s n'existe pas !",
+		"Hold %s does not exist!":                                                     "La réservation %s n'existe pas !",
+		"Hold %s is %s!":                                                              "La réservation %s est à l'état %s !",
+		"Hold %s is not placed for you!":                                              "La réservation %s n'est pas faite en votre faveur !",
+		"Journal entry %s is not balanced for %s!":                                    "L'écriture comptable %s n'est pas équilibrée en %s !",
+		"Label %s does not exist in your address book!":                               "Le libellé %s n'existe pas dans votre carnet d'adresses !",
+		"Label does not exist for this address.":                                      "Le libellé n'existe pas pour cette adresse.",
+		"Label of receiver does not exist!":                                           "Le libellé du destinataire n'existe pas !",
+		"Label of sender does not exist!":                                             "Le libellé de l'expéditeur n'existe pas !",
+		"Line %d should have address and label!":                                      "La ligne %d doit contenir une adresse et un libellé !",
+		"Merchant %s does not exist!":                                                 "Le commerçant %s n'existe pas !",
+		"Name %s already exists!":                                                     "Le nom %s existe déjà !",
+		"Name %s already points to %s!":                                               "Le nom %s pointe déjà vers %s !",
+		"Name %s has expired!":                                                        "Le nom %s a expiré !",
+		"Name %s is already registered!":                                              "Le nom %s est déjà enregistré !",
+		"Name %s is not registered by you!":                                           "Le nom %s n'est pas enregistré par vous !",
+		"Name %s is not registered!":                                                  "Le nom %s n'est pas enregistré !",
+		"Only the arbiter of escrow %s can decide its dispute!":                       "Seul l'arbitre du séquestre %s peut trancher son litige !",
+		"Only the issuer of %s can manage its allowlist!":                             "Seul l'émetteur de %s peut gérer sa liste d'autorisation !",
+		"Only the issuer of %s can restrict its transfers!":                           "Seul l'émetteur de %s peut restreindre ses transferts !",
+		"Only the issuer or the transfer agent of %s can review its transfers!":       "Seul l'émetteur ou l'agent de transfert de %s peut examiner ses transferts !",
+		"Payer %s does not exist!":                                                    "Le payeur %s n'existe pas !",
+		"Payment request %s does not exist!":                                          "La demande de paiement %s n'existe pas !",
+		"Payment request %s is %s!":                                                   "La demande de paiement %s est à l'état %s !",
+		"Payment request %s is not addressed to you!":                                 "La demande de paiement %s ne vous est pas adressée !",
+		"Payment request %s waits for the approval of the transfer %s!":               "La demande de paiement %s attend l'approbation du transfert %s !",
+		"Please select two different addresses!":                                      "Veuillez sélectionner deux adresses différentes !",
+		"Primary address can't be retired, please set another primary address first!": "L'adresse principale ne peut pas être retirée, veuillez d'abord définir une autre adresse principale !",
+		"Private data %s does not exist!":                                             "Les données privées %s n'existent pas !",
+		"Public key of user %s is not a valid ECDSA key!":                             "La clé publique de l'utilisateur %s n'est pas une clé ECDSA valide !",
+		"Quantity should be less or equal to %s":                                      "La quantité doit être inférieure ou égale à %s",
+		"Receiver %s has not registered a memo key!":                                  "Le destinataire %s n'a pas enregistré de clé de libellé !",
+		"Receiver %s does not exist!":                                                 "Le destinataire %s n'existe pas !",
+		"Receiver %s is not on the allowlist of %s!":                                  "Le destinataire %s ne figure pas sur la liste d'autorisation de %s !",
+		"Record does not exist in your address book.":                                 "L'enregistrement n'existe pas dans votre carnet d'adresses.",
+		"Request %s has already been used with another payload!":                      "La requête %s a déjà été utilisée avec d'autres données !",
+		"Restricted asset %s can't be escrowed!":                                      "L'actif restreint %s ne peut pas être placé sous séquestre !",
+		"Seller %s does not exist!":                                                   "Le vendeur %s n'existe pas !",
+		"Symbol %s already exists!":                                                   "Le symbole %s existe déjà !",
+		"Symbol %s does not exist!":                                                   "Le symbole %s n'existe pas !",
+		"The arbiter can't be a party of the escrow!":                                 "L'arbitre ne peut pas être une partie du séquestre !",
+		"This action requires %s!":                                                    "Cette action nécessite %s !",
+		"This action requires the %s role!":                                           "Cette action nécessite le rôle %s !",
+		"This address %s already exists in the system!":                               "L'adresse %s existe déjà dans le système !",
+		"This label %s has already been taken!":                                       "Le libellé %s est déjà utilisé !",
+		"This label already exists!":                                                  "Ce libellé existe déjà !",
+		"Transfer %s can't be refunded before %s!":                                    "Le transfert %s ne peut pas être remboursé avant %s !",
+		"Transfer %s does not exist!":                                                 "Le transfert %s n'existe pas !",
+		"Transfer %s has expired!":                                                    "Le transfert %s a expiré !",
+		"Transfer %s is already %s!":                                                  "Le transfert %s est déjà à l'état %s !",
+		"Transfer %s is not addressed to you!":                                        "Le transfert %s ne vous est pas adressé !",
+		"Transfer %s was not sent by you!":                                            "Le transfert %s n'a pas été envoyé par vous !",
+		"Transient data %s is required!":                                              "Les données transitoires %s sont obligatoires !",
+		"Unexpected error, correlation ID %s":                                         "Erreur inattendue, ID de corrélation %s",
+		"User %s already has the %s role!":                                            "L'utilisateur %s a déjà le rôle %s !",
+		"User %s does not exist!":                                                     "L'utilisateur %s n'existe pas !",
+		"User %s does not have the %s role!":                                          "L'utilisateur %s n'a pas le rôle %s !",
+		"User already exists with the given address %s!":                              "Un utilisateur existe déjà avec l'adresse %s !",
+		"User does not exist in this system!":                                         "L'utilisateur n'existe pas dans ce système !",
+		"You account %s does not exist!":                                              "Votre compte %s n'existe pas !",
+		"You are not a party of escrow %s!":                                           "Vous n'êtes pas une partie du séquestre %s !",
+		"You can't act as user %s!":                                                   "Vous ne pouvez pas agir en tant qu'utilisateur %s !",
+		"You can't open an escrow with yourself!":                                     "Vous ne pouvez pas ouvrir un séquestre avec vous-même !",
+		"You can't place a hold for yourself!":                                        "Vous ne pouvez pas faire une réservation pour vous-même !",
+		"You can't request a payment from yourself!":                                  "Vous ne pouvez pas vous demander un paiement à vous-même !",
+		"You can't transfer asset to yourself!":                                       "Vous ne pouvez pas vous transférer un actif à vous-même !",
+		"You can't transfer coins to yourself!":                                       "Vous ne pouvez pas vous transférer des pièces à vous-même !",
+		"You don't have enough coins to purchase this asset.":                         "Vous n'avez pas assez de pièces pour acheter cet actif.",
+		"You don't have enough coins to transfer the asset.":                          "Vous n'avez pas assez de pièces pour transférer l'actif.",
+	})
+}
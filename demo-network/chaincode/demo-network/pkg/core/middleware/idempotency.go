@@ -0,0 +1,107 @@
+// Package middleware Idempotency of the invocations which the client app retries
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/auth"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/convert"
+	"github.com/s7techlab/cckit/router"
+)
+
+// RequestRecord the outcome of an invocation with a client request ID, kept under the invoker and
+// the request ID
+type RequestRecord struct {
+	RequestID   string `json:"request_id"`
+	Function    string `json:"function"`
+	PayloadHash string `json:"payload_hash"`
+	Response    []byte `json:"response"`
+	DocType     string `json:"doc_type"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// Idempotent makes the route safe to retry. When the invoker passes a request ID, the response is
+// saved under the invoker and the request ID. A repeat with the same payload returns the saved
+// response without calling the handler again, a repeat with another payload is a conflict
+func Idempotent(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+	return func(c router.Context) (interface{}, error) {
+		requestID := RequestID(c)
+		if requestID == "" {
+			return next(c)
+		}
+
+		claims, err := auth.FromContext(c)
+		if err != nil {
+			return nil, err
+		}
+		key := []string{utils.DocTypeRequest, claims.MSPID, claims.EnrollmentID, requestID}
+		payloadHash := hashPayload(c)
+
+		exists, err := c.State().Exists(key)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		if exists {
+			recordData, err := c.State().Get(key, &RequestRecord{})
+			if err != nil {
+				return nil, status.ErrInternal.WithError(err)
+			}
+			record := recordData.(RequestRecord)
+			if record.PayloadHash != payloadHash {
+				return nil, status.ErrRequestReused.WithMessagef("Request %s has already been used with another payload!", requestID)
+			}
+			return record.Response, nil
+		}
+
+		response, err := next(c)
+		if err != nil {
+			return nil, err
+		}
+		responseBytes, err := convert.ToBytes(response)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+
+		createdAt, err := utils.TxTime(c)
+		if err != nil {
+			return nil, err
+		}
+		record := RequestRecord{RequestID: requestID, Function: c.Path(), PayloadHash: payloadHash, Response: responseBytes, DocType: utils.DocTypeRequest, CreatedAt: createdAt.Format(time.RFC3339)}
+		return responseBytes, c.State().Put(key, record)
+	}
+}
+
+// hashPayload returns the hash of the args. The transient entries are left out, their hash would
+// be kept on the ledger where the private data could be guessed from it
+func hashPayload(c router.Context) string {
+	return utils.Hash(string(bytes.Join(c.GetArgs(), []byte{0})))
+}
+
+// RequestID returns the client request ID of the invocation. It is taken from the request_id entry
+// of the transient map or the request_id field of the payload, it is empty when there is none
+func RequestID(c router.Context) string {
+	requestID := ""
+	transient, err := c.Stub().GetTransient()
+	if err == nil && transient[utils.TransientRequestIDKey] != nil {
+		_ = json.Unmarshal(transient[utils.TransientRequestIDKey], &requestID)
+	}
+	if requestID != "" {
+		return requestID
+	}
+
+	args := c.Stub().GetArgs()
+	for i := 1; i < len(args); i++ {
+		payload := struct {
+			RequestID string `json:"request_id"`
+		}{}
+		if json.Unmarshal(args[i], &payload) == nil && payload.RequestID != "" {
+			return payload.RequestID
+		}
+	}
+	return ""
+}
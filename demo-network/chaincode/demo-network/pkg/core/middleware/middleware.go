@@ -0,0 +1,149 @@
+// Package middleware Router middleware shared by all the chaincode functions
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/i18n"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/s7techlab/cckit/router"
+	"github.com/s7techlab/cckit/router/param"
+)
+
+// Struct parses the chaincode function argument into the param like param.Struct, rejects the
+// unknown fields and then validates the params before the handler is called
+func Struct(name string, target interface{}) router.MiddlewareFunc {
+	parse := param.Struct(name, target)
+	return func(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+		return parse(strict(target, Validate(next, pos...)), pos...)
+	}
+}
+
+// strict rejects the fields of the parsed argument which the target does not have. The fields
+// which the client app adds to every payload are allowed
+func strict(target interface{}, next router.HandlerFunc) router.HandlerFunc {
+	return func(c router.Context) (interface{}, error) {
+		argPos, _ := c.Param(param.LastPosKey).(int)
+		args := c.GetArgs()
+		if argPos+1 >= len(args) {
+			return next(c)
+		}
+
+		fields := map[string]json.RawMessage{}
+		if json.Unmarshal(args[argPos+1], &fields) != nil {
+			return next(c)
+		}
+		delete(fields, utils.PayloadUserKey)
+		delete(fields, utils.PayloadLocaleKey)
+		delete(fields, utils.PayloadRequestIDKey)
+		payload, err := json.Marshal(fields)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(payload))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(reflect.New(reflect.TypeOf(target).Elem()).Interface())
+		if err != nil && strings.HasPrefix(err.Error(), "json: unknown field ") {
+			field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+			return nil, status.ErrStatusUnprocessableEntity.WithMessagef("Field %s is not allowed!", field)
+		}
+		return next(c)
+	}
+}
+
+// Validate validates every param which implements validation.Validatable
+func Validate(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+	return func(c router.Context) (interface{}, error) {
+		params := c.Params()
+		names := make([]string, 0, len(params))
+		for name := range params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if value, ok := params[name].(validation.Validatable); ok {
+				if err := Validated(value); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return next(c)
+	}
+}
+
+// Validated validates the value and returns the error status of the failed rules
+func Validated(value validation.Validatable) error {
+	err := value.Validate()
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(validation.InternalError); ok {
+		return err
+	}
+	if errs, ok := err.(validation.Errors); ok {
+		return status.ErrStatusUnprocessableEntity.WithValidationError(errs)
+	}
+	return status.ErrStatusUnprocessableEntity.WithError(err)
+}
+
+// Recover turns a panic of the handler into an internal error. The transaction ID is returned
+// as the correlation ID so that the error can be found in the peer logs
+func Recover(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+	return func(c router.Context) (response interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				correlationID := c.Stub().GetTxID()
+				c.Logger().Errorf("panic in %s, correlation ID %s: %v\n%s", c.Path(), correlationID, r, debug.Stack())
+
+				errStatus := status.ErrInternal.WithMessagef("Unexpected error, correlation ID %s", correlationID)
+				errStatus.AddDtl("correlation_id", correlationID)
+				response, err = nil, errStatus
+			}
+		}()
+		return next(c)
+	}
+}
+
+// Log logs the function name, the transaction ID, the duration and the outcome of every invocation
+func Log(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+	return func(c router.Context) (interface{}, error) {
+		start := time.Now()
+		response, err := next(c)
+		duration := time.Since(start)
+
+		if err != nil {
+			c.Logger().Warningf("function=%s tx_id=%s duration=%s outcome=error error=%s", c.Path(), c.Stub().GetTxID(), duration, err)
+		} else {
+			c.Logger().Infof("function=%s tx_id=%s duration=%s outcome=ok", c.Path(), c.Stub().GetTxID(), duration)
+		}
+		return response, err
+	}
+}
+
+// Localize translates the message and the details of the error status into the locale of the
+// invocation. The error code and the detail keys are left as they are
+func Localize(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+	return func(c router.Context) (interface{}, error) {
+		response, err := next(c)
+		errStatus, ok := err.(status.ErrServiceStatus)
+		if !ok {
+			return response, err
+		}
+
+		locale := i18n.Locale(c)
+		return response, errStatus.Localize(func(message string) string {
+			return i18n.Translate(locale, message)
+		})
+	}
+}
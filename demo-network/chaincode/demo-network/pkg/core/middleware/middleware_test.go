@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chaincode/demo-network/pkg/core/rules"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/s7techlab/cckit/router"
+)
+
+// testPayload the payload of the test route
+type testPayload struct {
+	UserID string `json:"user_id"`
+	Label  string `json:"label"`
+}
+
+// Validate Validates the testPayload Structure
+func (data testPayload) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Label, rules.Label...),
+	)
+}
+
+func TestStructRejectsUnknownFields(t *testing.T) {
+	var received testPayload
+	r := router.New(`test`).Invoke(`setLabel`, func(c router.Context) (interface{}, error) {
+		received = c.Param(`data`).(testPayload)
+		return received, nil
+	}, Struct(`data`, &testPayload{}))
+	stub := shim.NewMockStub(`test`, router.NewChaincode(r))
+
+	tests := []struct {
+		name    string
+		payload string
+		err     string
+	}{
+		{"known fields", `{"user_id":"u1","label":"Rent"}`, ""},
+		{"fields of the client app", `{"user_id":"u1","label":"Rent","user":"alice","locale":"fr","request_id":"r1"}`, ""},
+		{"unknown field", `{"user_id":"u1","label":"Rent","doc_type":"user"}`, "Field doc_type is not allowed!"},
+		{"misspelt field", `{"userid":"u1","label":"Rent"}`, "Field userid is not allowed!"},
+		{"invalid value", `{"user_id":"u1","label":"Rent{}"}`, "VALIDATION_FAILED"},
+	}
+	for _, test := range tests {
+		received = testPayload{}
+		response := stub.MockInvoke(`tx`, [][]byte{[]byte(`setLabel`), []byte(test.payload)})
+		if test.err == "" {
+			if response.Status != shim.OK {
+				t.Errorf("%s: got %d %s", test.name, response.Status, response.Message)
+			}
+			if received.UserID != "u1" || received.Label != "Rent" {
+				t.Errorf("%s: the handler received %+v", test.name, received)
+			}
+			continue
+		}
+		if response.Status == shim.OK || !strings.Contains(response.Message, test.err) {
+			t.Errorf("%s: got %d %s, want %s", test.name, response.Status, response.Message, test.err)
+		}
+		if received != (testPayload{}) {
+			t.Errorf("%s: the handler was called with %+v", test.name, received)
+		}
+	}
+}
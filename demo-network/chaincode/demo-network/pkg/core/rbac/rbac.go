@@ -0,0 +1,185 @@
+// Package rbac Role based access control on top of the chaincode owner. The owner recorded at
+// instantiation always holds the admin role, the other grants are kept in the world state
+package rbac
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/auth"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/extensions/owner"
+	"github.com/s7techlab/cckit/router"
+)
+
+// Only allows the route to the invokers holding any of the roles
+func Only(roles ...string) router.MiddlewareFunc {
+	return func(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+		return func(c router.Context) (interface{}, error) {
+			allowed, err := HasRole(c, roles...)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				return nil, status.ErrForbidden.WithMessagef("This action requires the %s role!", strings.Join(roles, " or "))
+			}
+			return next(c)
+		}
+	}
+}
+
+// HasRole checks whether the invoker holds any of the roles
+func HasRole(c router.Context, roles ...string) (bool, error) {
+	mspID, user, err := Invoker(c)
+	if err != nil {
+		return false, err
+	}
+
+	for _, role := range roles {
+		if role == utils.RoleAdmin {
+			isOwner, err := owner.IsInvoker(c)
+			if err != nil {
+				return false, status.ErrInternal.WithError(err)
+			}
+			if isOwner {
+				return true, nil
+			}
+		}
+
+		granted, err := c.State().Exists([]string{utils.DocTypeRoleGrant, mspID, user, role})
+		if err != nil {
+			return false, status.ErrInternal.WithError(err)
+		}
+		if granted {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Invoker returns the organization and the enrollment ID of the invoker
+func Invoker(c router.Context) (string, string, error) {
+	claims, err := auth.FromContext(c)
+	if err != nil {
+		return "", "", err
+	}
+	return claims.MSPID, claims.EnrollmentID, nil
+}
+
+// GrantRole grant the role to the user of the organization
+func GrantRole(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(RoleRequest)
+
+	key := []string{utils.DocTypeRoleGrant, data.MSPID, data.User, data.Role}
+	granted, err := c.State().Exists(key)
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+	if granted {
+		return nil, status.ErrRoleGranted.WithMessagef("User %s already has the %s role!", data.User, data.Role)
+	}
+
+	actor, err := audit(c, utils.RoleGranted, data)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	grant := RoleGrant{MSPID: data.MSPID, User: data.User, Role: data.Role, GrantedBy: actor, DocType: utils.DocTypeRoleGrant, CreatedAt: createdAt.Format(time.RFC3339)}
+
+	// Save the data and return the response
+	return grant, c.State().Put(key, grant)
+}
+
+// RevokeRole revoke the role from the user of the organization
+func RevokeRole(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(RoleRequest)
+
+	key := []string{utils.DocTypeRoleGrant, data.MSPID, data.User, data.Role}
+	granted, err := c.State().Exists(key)
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+	if !granted {
+		return nil, status.ErrRoleNotGranted.WithMessagef("User %s does not have the %s role!", data.User, data.Role)
+	}
+
+	_, err = audit(c, utils.RoleRevoked, data)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := utils.ResponseMessage{Message: fmt.Sprintf("The %s role has been revoked from %s.", data.Role, data.User)}
+
+	// Delete the data and return the response
+	return responseBody, c.State().Delete(key)
+}
+
+// ListRoles get the current role grants
+func ListRoles(c router.Context) (interface{}, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"doc_type\":\"%s\"}}", utils.DocTypeRoleGrant)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := RoleGrantsResponse{Grants: []RoleGrant{}}
+	for _, result := range results {
+		grant := RoleGrant{}
+		err = json.Unmarshal(result.Value, &grant)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		responseBody.Grants = append(responseBody.Grants, grant)
+	}
+
+	// return the response
+	return responseBody, nil
+}
+
+// ListAdminAudit get the audit trail of the role grants and revokes, latest first
+func ListAdminAudit(c router.Context) (interface{}, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"doc_type\":\"%s\"},\"sort\":[{\"created_at\":\"desc\"}]}", utils.DocTypeAdminAudit)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := AdminAuditResponse{Entries: []AdminAudit{}}
+	for _, result := range results {
+		entry := AdminAudit{}
+		err = json.Unmarshal(result.Value, &entry)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		responseBody.Entries = append(responseBody.Entries, entry)
+	}
+
+	// return the response
+	return responseBody, nil
+}
+
+// audit records the action on the role in the admin audit trail and returns the actor
+func audit(c router.Context, action string, data RoleRequest) (string, error) {
+	mspID, user, err := Invoker(c)
+	if err != nil {
+		return "", err
+	}
+	actor := mspID + ":" + user
+	createdAt, err := utils.TxTime(c)
+	if err != nil {
+		return "", err
+	}
+
+	entry := AdminAudit{Action: action, MSPID: data.MSPID, User: data.User, Role: data.Role, Actor: actor, DocType: utils.DocTypeAdminAudit, CreatedAt: createdAt.Format(time.RFC3339)}
+	return actor, c.State().Put([]string{utils.DocTypeAdminAudit, c.Stub().GetTxID()}, entry)
+}
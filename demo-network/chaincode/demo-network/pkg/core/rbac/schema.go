@@ -0,0 +1,40 @@
+// Package rbac Related structures
+package rbac
+
+// Define the RoleGrant structure, a role held by the identity of an organization
+type RoleGrant struct {
+	MSPID     string `json:"msp_id"`
+	User      string `json:"user"`
+	Role      string `json:"role"`
+	GrantedBy string `json:"granted_by"`
+	DocType   string `json:"doc_type"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Define the RoleRequest structure
+type RoleRequest struct {
+	MSPID string `json:"msp_id"`
+	User  string `json:"user"`
+	Role  string `json:"role"`
+}
+
+// Define the AdminAudit structure, an entry of the admin audit trail
+type AdminAudit struct {
+	Action    string `json:"action"`
+	MSPID     string `json:"msp_id"`
+	User      string `json:"user"`
+	Role      string `json:"role"`
+	Actor     string `json:"actor"`
+	DocType   string `json:"doc_type"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Define the RoleGrantsResponse structure
+type RoleGrantsResponse struct {
+	Grants []RoleGrant `json:"grants"`
+}
+
+// Define the AdminAuditResponse structure
+type AdminAuditResponse struct {
+	Entries []AdminAudit `json:"entries"`
+}
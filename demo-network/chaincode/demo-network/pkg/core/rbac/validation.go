@@ -0,0 +1,17 @@
+// Package rbac Validations
+package rbac
+
+import (
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Validate Validates the RoleRequest Structure
+func (data RoleRequest) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.MSPID, validation.Required.Error(utils.MSPIDRequired), validation.NotNil.Error(utils.MSPIDRequired)),
+		validation.Field(&data.User, validation.Required.Error(utils.UserRequired), validation.NotNil.Error(utils.UserRequired)),
+		validation.Field(&data.Role, validation.Required.Error(utils.RoleRequired), validation.In(utils.RoleAdmin, utils.RoleIssuer, utils.RoleCompliance, utils.RoleAuditor, utils.RoleOperator).Error(utils.RoleInvalid)),
+	)
+}
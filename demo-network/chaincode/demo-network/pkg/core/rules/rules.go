@@ -0,0 +1,147 @@
+// Package rules Domain validation rules shared by the payload structures
+package rules
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+
+	"github.com/chaincode/demo-network/pkg/core/amount"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
+// MaxLabelLength the longest label of an address, contact or asset
+const MaxLabelLength = 50
+
+// MaxMemoLength the longest memo of a transfer
+const MaxMemoLength = 140
+
+// MaxEncryptedMemoLength the longest base64 ciphertext of a private memo, a memo of the longest
+// length encrypted to a P-256 or a P-384 key fits
+const MaxEncryptedMemoLength = 1024
+
+// MaxNameLength the longest name registered for an address
+const MaxNameLength = 64
+
+// MaxAmount the largest quantity of a single operation, the sums stay far from overflow
+var MaxAmount = amount.Units(1000000000)
+
+var (
+	assetCodeFormat = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,9}$`)
+	labelFormat     = regexp.MustCompile(`^[\p{L}\p{N} ._'-]+$`)
+	addressFormat   = regexp.MustCompile(`^[A-Za-z0-9]{26,64}$`)
+	nameFormat      = regexp.MustCompile(`^[a-z0-9-]+(\.[a-z0-9-]+)+$`)
+	recipientFormat = regexp.MustCompile(`^([A-Za-z0-9]{26,64}|[a-z0-9-]+(\.[a-z0-9-]+)+)$`)
+)
+
+// Amount a positive and bounded quantity of coins or asset
+var Amount = []validation.Rule{amountRule{}}
+
+// OptionalAmount a positive and bounded quantity, it may be zero when the quantity is implied
+var OptionalAmount = []validation.Rule{amountRule{optional: true}}
+
+// OptionalAssetCode an uppercase asset code of 2 to 10 characters, it may be empty
+var OptionalAssetCode = []validation.Rule{
+	validation.Match(assetCodeFormat).Error(utils.CodeInvalid),
+}
+
+// AssetCode a required uppercase asset code of 2 to 10 characters
+var AssetCode = append([]validation.Rule{validation.Required.Error(utils.CodeRequired)}, OptionalAssetCode...)
+
+// OptionalLabel a label of limited length and charset, it may be empty
+var OptionalLabel = []validation.Rule{
+	validation.RuneLength(0, MaxLabelLength).Error(utils.LabelInvalid),
+	validation.Match(labelFormat).Error(utils.LabelInvalid),
+}
+
+// Label a required label of limited length and charset
+var Label = append([]validation.Rule{validation.Required.Error(utils.LabelRequired)}, OptionalLabel...)
+
+// Memo a free-text reference of a transfer, it may be empty
+var Memo = []validation.Rule{
+	validation.RuneLength(0, MaxMemoLength).Error(utils.MemoInvalid),
+}
+
+// EncryptedMemo the base64 ciphertext of a private memo, it may be empty
+var EncryptedMemo = []validation.Rule{
+	validation.Length(0, MaxEncryptedMemoLength).Error(utils.EncryptedMemoInvalid),
+	is.Base64.Error(utils.EncryptedMemoInvalid),
+}
+
+// OptionalAddress a wallet address, it may be empty
+var OptionalAddress = []validation.Rule{
+	validation.Match(addressFormat).Error(utils.AddressInvalid),
+}
+
+// Address a required wallet address
+var Address = append([]validation.Rule{validation.Required.Error(utils.AddressRequired)}, OptionalAddress...)
+
+// AddressName a required name of an address such as alice.wallet
+var AddressName = []validation.Rule{
+	validation.Required.Error(utils.NameRequired),
+	validation.Length(0, MaxNameLength).Error(utils.AddressNameInvalid),
+	validation.Match(nameFormat).Error(utils.AddressNameInvalid),
+}
+
+// Recipient a required wallet address or a name registered for one
+var Recipient = []validation.Rule{
+	validation.Required.Error(utils.AddressRequired),
+	validation.Length(0, MaxNameLength).Error(utils.RecipientInvalid),
+	validation.Match(recipientFormat).Error(utils.RecipientInvalid),
+}
+
+// IsAddressName reports whether the recipient is a registered name rather than an address
+func IsAddressName(recipient string) bool {
+	return nameFormat.MatchString(recipient)
+}
+
+// amountRule the rule of Amount and OptionalAmount
+type amountRule struct {
+	optional bool
+}
+
+// Validate validates the amount is present, positive and not above MaxAmount
+func (r amountRule) Validate(value interface{}) error {
+	quantity, ok := value.(amount.Amount)
+	if !ok {
+		return nil
+	}
+	switch {
+	case quantity.IsZero() && r.optional:
+		return nil
+	case quantity.IsZero():
+		return errors.New(utils.QuantityRequired)
+	case quantity.Sign() < 0:
+		return errors.New(utils.PositiveRequired)
+	case quantity.Cmp(MaxAmount) > 0:
+		return errors.New(utils.AmountTooLarge)
+	}
+	return nil
+}
+
+// Each applies the rules to every element of the slice
+func Each(rules ...validation.Rule) validation.Rule {
+	return each{rules: rules}
+}
+
+// each the rule returned by Each
+type each struct {
+	rules []validation.Rule
+}
+
+// Validate validates every element of the slice and returns the first error
+func (r each) Validate(value interface{}) error {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice {
+		return nil
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := validation.Validate(v.Index(i).Interface(), r.rules...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chaincode/demo-network/pkg/core/amount"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ruleTest a value and the message of the rules which it fails, empty when it is valid
+type ruleTest struct {
+	name  string
+	value interface{}
+	err   string
+}
+
+// runRuleTests validates every value of the tests with the rules
+func runRuleTests(t *testing.T, rules []validation.Rule, tests []ruleTest) {
+	for _, test := range tests {
+		err := validation.Validate(test.value, rules...)
+		message := ""
+		if err != nil {
+			message = err.Error()
+		}
+		if message != test.err {
+			t.Errorf("%s: got %q, want %q", test.name, message, test.err)
+		}
+	}
+}
+
+// mustParse parses the decimal amount of the test
+func mustParse(t *testing.T, s string) amount.Amount {
+	a, err := amount.Parse(s)
+	if err != nil {
+		t.Fatalf("parse %s: %v", s, err)
+	}
+	return a
+}
+
+func TestAmount(t *testing.T) {
+	runRuleTests(t, Amount, []ruleTest{
+		{"one unit", amount.Units(1), ""},
+		{"fraction", mustParse(t, "0.00000001"), ""},
+		{"maximum", MaxAmount, ""},
+		{"zero", amount.Units(0), utils.QuantityRequired},
+		{"zero with decimals", mustParse(t, "0.000"), utils.QuantityRequired},
+		{"negative", amount.Units(-1), utils.PositiveRequired},
+		{"negative fraction", mustParse(t, "-0.5"), utils.PositiveRequired},
+		{"above maximum", mustParse(t, "1000000000.00000001"), utils.AmountTooLarge},
+		{"far above maximum", amount.Units(1000000000000), utils.AmountTooLarge},
+	})
+}
+
+func TestOptionalAmount(t *testing.T) {
+	runRuleTests(t, OptionalAmount, []ruleTest{
+		{"zero", amount.Units(0), ""},
+		{"one unit", amount.Units(1), ""},
+		{"negative", amount.Units(-1), utils.PositiveRequired},
+		{"above maximum", amount.Units(1000000001), utils.AmountTooLarge},
+	})
+}
+
+func TestAssetCode(t *testing.T) {
+	runRuleTests(t, AssetCode, []ruleTest{
+		{"shortest", "AB", ""},
+		{"longest", "ABCDEFGHIJ", ""},
+		{"digits", "ABTC2", ""},
+		{"empty", "", utils.CodeRequired},
+		{"too short", "A", utils.CodeInvalid},
+		{"too long", "ABCDEFGHIJK", utils.CodeInvalid},
+		{"lowercase", "abtc", utils.CodeInvalid},
+		{"leading digit", "2BTC", utils.CodeInvalid},
+		{"space", "AB TC", utils.CodeInvalid},
+		{"selector injection", `ABTC","doc_type":"user`, utils.CodeInvalid},
+	})
+}
+
+func TestLabel(t *testing.T) {
+	runRuleTests(t, Label, []ruleTest{
+		{"words", "Savings account", ""},
+		{"punctuation", "O'Brien_rent-2.0", ""},
+		{"unicode letters", "Épargne Zürich", ""},
+		{"longest", strings.Repeat("a", MaxLabelLength), ""},
+		{"longest in unicode", strings.Repeat("ü", MaxLabelLength), ""},
+		{"empty", "", utils.LabelRequired},
+		{"too long", strings.Repeat("a", MaxLabelLength+1), utils.LabelInvalid},
+		{"quote", `rent"`, utils.LabelInvalid},
+		{"markup", "<b>rent</b>", utils.LabelInvalid},
+		{"newline", "rent\nfood", utils.LabelInvalid},
+	})
+	runRuleTests(t, OptionalLabel, []ruleTest{
+		{"empty", "", ""},
+		{"invalid", "rent{}", utils.LabelInvalid},
+	})
+}
+
+func TestAddress(t *testing.T) {
+	runRuleTests(t, Address, []ruleTest{
+		{"bitcoin", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", ""},
+		{"shortest", strings.Repeat("a", 26), ""},
+		{"longest", strings.Repeat("a", 64), ""},
+		{"empty", "", utils.AddressRequired},
+		{"too short", strings.Repeat("a", 25), utils.AddressInvalid},
+		{"too long", strings.Repeat("a", 65), utils.AddressInvalid},
+		{"dash", "1BvBMSEYstWetqTFn5Au4m4GFg7x-aNVN2", utils.AddressInvalid},
+		{"space", "1BvBMSEYstWetqTFn5Au4m4GFg7 JaNVN2", utils.AddressInvalid},
+		{"selector injection", `1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"}`, utils.AddressInvalid},
+	})
+	runRuleTests(t, OptionalAddress, []ruleTest{
+		{"empty", "", ""},
+		{"too short", "abc", utils.AddressInvalid},
+	})
+}
+
+func TestRecipient(t *testing.T) {
+	runRuleTests(t, Recipient, []ruleTest{
+		{"address", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", ""},
+		{"name", "alice.wallet", ""},
+		{"nested name", "rent.alice-2.wallet", ""},
+		{"empty", "", utils.AddressRequired},
+		{"name without a dot", "alice", utils.RecipientInvalid},
+		{"uppercase name", "Alice.wallet", utils.RecipientInvalid},
+		{"empty part", "alice..wallet", utils.RecipientInvalid},
+		{"short address", "1BvBMSEY", utils.RecipientInvalid},
+		{"too long name", strings.Repeat("a", MaxNameLength) + ".wallet", utils.RecipientInvalid},
+	})
+
+	tests := []struct {
+		recipient string
+		name      bool
+	}{
+		{"alice.wallet", true},
+		{"rent.alice-2.wallet", true},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", false},
+		{"alice", false},
+	}
+	for _, test := range tests {
+		if IsAddressName(test.recipient) != test.name {
+			t.Errorf("IsAddressName(%q) = %v, want %v", test.recipient, !test.name, test.name)
+		}
+	}
+}
+
+func TestAddressName(t *testing.T) {
+	runRuleTests(t, AddressName, []ruleTest{
+		{"name", "alice.wallet", ""},
+		{"empty", "", utils.NameRequired},
+		{"address", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", utils.AddressNameInvalid},
+		{"too long", strings.Repeat("a", MaxNameLength-6) + ".wallet", utils.AddressNameInvalid},
+	})
+}
+
+func TestMemo(t *testing.T) {
+	runRuleTests(t, Memo, []ruleTest{
+		{"empty", "", ""},
+		{"longest", strings.Repeat("é", MaxMemoLength), ""},
+		{"too long", strings.Repeat("a", MaxMemoLength+1), utils.MemoInvalid},
+	})
+	runRuleTests(t, EncryptedMemo, []ruleTest{
+		{"empty", "", ""},
+		{"base64", "BJ2k1w5bQ0rBvM8aTnE4yQ==", ""},
+		{"not base64", "not a ciphertext!", utils.EncryptedMemoInvalid},
+		{"too long", strings.Repeat("A", MaxEncryptedMemoLength+4), utils.EncryptedMemoInvalid},
+	})
+}
+
+func TestEach(t *testing.T) {
+	runRuleTests(t, []validation.Rule{Each(Address...)}, []ruleTest{
+		{"all valid", []string{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", strings.Repeat("b", 26)}, ""},
+		{"empty slice", []string{}, ""},
+		{"one invalid", []string{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "short"}, utils.AddressInvalid},
+		{"one empty", []string{""}, utils.AddressRequired},
+	})
+}
@@ -0,0 +1,237 @@
+// Package status Catalog of the stable error codes returned by the chaincode
+package status
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// ErrInsufficientBalance represents a spend of more coins than the address holds.
+var ErrInsufficientBalance = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusUnprocessableEntity, ErrorCode: "INSUFFICIENT_BALANCE", Message: "You don't have enough coins"},
+}
+
+// ErrInsufficientAsset represents a spend of more asset than the address holds.
+var ErrInsufficientAsset = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusUnprocessableEntity, ErrorCode: "INSUFFICIENT_ASSET", Message: "You don't have enough quantity of the asset"},
+}
+
+// ErrSelfTransfer represents a transfer to an address of the sender.
+var ErrSelfTransfer = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusUnprocessableEntity, ErrorCode: "SELF_TRANSFER", Message: "You can't transfer to yourself"},
+}
+
+// ErrSameAddress represents a move between the same address.
+var ErrSameAddress = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusUnprocessableEntity, ErrorCode: "SAME_ADDRESS", Message: "Please select two different addresses"},
+}
+
+// ErrAddressRetired represents a use of a retired address.
+var ErrAddressRetired = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusUnprocessableEntity, ErrorCode: "ADDRESS_RETIRED", Message: "Address has been retired"},
+}
+
+// ErrPrimaryAddress represents a retire of the primary address.
+var ErrPrimaryAddress = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusUnprocessableEntity, ErrorCode: "PRIMARY_ADDRESS", Message: "Primary address can't be retired"},
+}
+
+// ErrAssetCodeTaken represents an asset symbol which already exists.
+var ErrAssetCodeTaken = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "ASSET_CODE_TAKEN", Message: "Symbol already exists"},
+}
+
+// ErrAssetNameTaken represents an asset name which already exists.
+var ErrAssetNameTaken = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "ASSET_NAME_TAKEN", Message: "Name already exists"},
+}
+
+// ErrLabelTaken represents a label which has already been taken.
+var ErrLabelTaken = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "LABEL_TAKEN", Message: "This label has already been taken"},
+}
+
+// ErrAddressTaken represents an address which already exists in the system.
+var ErrAddressTaken = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "ADDRESS_TAKEN", Message: "This address already exists in the system"},
+}
+
+// ErrContactExists represents an address which already exists in the address book.
+var ErrContactExists = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "CONTACT_EXISTS", Message: "Address already exists in your address book"},
+}
+
+// ErrAlertResolved represents an alert which is already resolved.
+var ErrAlertResolved = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "ALERT_RESOLVED", Message: "Alert is already resolved"},
+}
+
+// ErrRoleGranted represents a role which the user already has.
+var ErrRoleGranted = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "ROLE_ALREADY_GRANTED", Message: "User already has the role"},
+}
+
+// ErrUserNotFound represents a user which does not exist.
+var ErrUserNotFound = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusNotFound, ErrorCode: "USER_NOT_FOUND", Message: "User does not exist"},
+}
+
+// ErrInvalidSecret represents a secret which does not match any user.
+var ErrInvalidSecret = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusUnauthorized, ErrorCode: "INVALID_SECRET", Message: "User does not exist in this system"},
+}
+
+// ErrAddressNotOwned represents an address which does not belong to the user.
+var ErrAddressNotOwned = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusNotFound, ErrorCode: "ADDRESS_NOT_OWNED", Message: "Address does not belong to you"},
+}
+
+// ErrAddressNotBlocked represents an address which is not on the blocked list.
+var ErrAddressNotBlocked = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusNotFound, ErrorCode: "ADDRESS_NOT_BLOCKED", Message: "Address is not blocked"},
+}
+
+// ErrLabelNotFound represents a label which does not exist in the address book.
+var ErrLabelNotFound = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusNotFound, ErrorCode: "LABEL_NOT_FOUND", Message: "Label does not exist"},
+}
+
+// ErrAlertNotFound represents an alert which does not exist.
+var ErrAlertNotFound = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusNotFound, ErrorCode: "ALERT_NOT_FOUND", Message: "Alert does not exist"},
+}
+
+// ErrTransferNotFound represents a confidential transfer which does not exist.
+var ErrTransferNotFound = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusNotFound, ErrorCode: "TRANSFER_NOT_FOUND", Message: "Transfer does not exist"},
+}
+
+// ErrRoleNotGranted represents a role which the user does not have.
+var ErrRoleNotGranted = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusNotFound, ErrorCode: "ROLE_NOT_GRANTED", Message: "User does not have the role"},
+}
+
+// ErrTransientRequired represents a missing entry of the transient map.
+var ErrTransientRequired = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusBadRequest, ErrorCode: "TRANSIENT_REQUIRED", Message: "Transient data is required"},
+}
+
+// ErrAmountInvalid represents an amount which is not a decimal number.
+var ErrAmountInvalid = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusUnprocessableEntity, ErrorCode: "AMOUNT_INVALID", Message: "Amount is not a decimal number"},
+}
+
+// ErrAmountOverflow represents an amount or a result of arithmetic which is too large.
+var ErrAmountOverflow = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusUnprocessableEntity, ErrorCode: "AMOUNT_OVERFLOW", Message: "Amount is too large"},
+}
+
+// ErrAmountPrecision represents an amount with more decimals than its code allows.
+var ErrAmountPrecision = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusUnprocessableEntity, ErrorCode: "AMOUNT_PRECISION", Message: "Amount has too many decimals"},
+}
+
+// ErrUnbalancedEntry represents a journal entry whose legs don't sum to zero.
+var ErrUnbalancedEntry = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusInternalServerError, ErrorCode: "UNBALANCED_ENTRY", Message: "Journal entry is not balanced"},
+}
+
+// ErrRequestReused represents a client request ID which was used with another payload.
+var ErrRequestReused = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "REQUEST_ID_REUSED", Message: "Request ID has already been used with another payload"},
+}
+
+// ErrMemoKeyRequired represents a private memo to a user who has not registered a memo key.
+var ErrMemoKeyRequired = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusUnprocessableEntity, ErrorCode: "MEMO_KEY_REQUIRED", Message: "Receiver has not registered a memo key"},
+}
+
+// ErrPaymentRequestNotFound represents a payment request which does not exist.
+var ErrPaymentRequestNotFound = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusNotFound, ErrorCode: "PAYMENT_REQUEST_NOT_FOUND", Message: "Payment request does not exist"},
+}
+
+// ErrPaymentRequestClosed represents a payment request which is settled, declined or expired.
+var ErrPaymentRequestClosed = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "PAYMENT_REQUEST_CLOSED", Message: "Payment request is no longer open"},
+}
+
+// ErrTransferNotPending represents a pending transfer which is already accepted, rejected or refunded.
+var ErrTransferNotPending = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "TRANSFER_NOT_PENDING", Message: "Transfer is no longer pending"},
+}
+
+// ErrNotAllowlisted represents a receiver which is not on the allowlist of the asset.
+var ErrNotAllowlisted = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusForbidden, ErrorCode: "NOT_ALLOWLISTED", Message: "Receiver is not on the allowlist of the asset"},
+}
+
+// ErrHoldNotFound represents a hold which does not exist.
+var ErrHoldNotFound = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusNotFound, ErrorCode: "HOLD_NOT_FOUND", Message: "Hold does not exist"},
+}
+
+// ErrHoldClosed represents a hold which is captured, released or expired.
+var ErrHoldClosed = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "HOLD_CLOSED", Message: "Hold is no longer active"},
+}
+
+// ErrEscrowNotFound represents an escrow which does not exist.
+var ErrEscrowNotFound = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusNotFound, ErrorCode: "ESCROW_NOT_FOUND", Message: "Escrow does not exist"},
+}
+
+// ErrEscrowClosed represents an escrow which can't change anymore in the requested way.
+var ErrEscrowClosed = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "ESCROW_CLOSED", Message: "Escrow can't be changed in its current status"},
+}
+
+// ErrNameTaken represents a name which another address has registered.
+var ErrNameTaken = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "NAME_TAKEN", Message: "Name is already registered"},
+}
+
+// ErrNameNotFound represents a name which is not registered or has expired.
+var ErrNameNotFound = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusNotFound, ErrorCode: "NAME_NOT_FOUND", Message: "Name is not registered"},
+}
+
+// ErrAddressBlocked represents a transaction which involves an address on the blocked list.
+var ErrAddressBlocked = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusForbidden, ErrorCode: "ADDRESS_BLOCKED", Message: "Address is on the blocked list"},
+}
+
+// Catalog the error statuses which the chaincode can return
+var Catalog = []ErrServiceStatus{
+	ErrInternal, ErrNotFound, ErrBadRequest, ErrUnauhtorized, ErrForbidden, ErrNotImplemented,
+	ErrContentTypeNotSupported, ErrStatusConflict, ErrStatusUnprocessableEntity,
+	ErrInsufficientBalance, ErrInsufficientAsset, ErrSelfTransfer, ErrSameAddress, ErrAddressRetired,
+	ErrPrimaryAddress, ErrAssetCodeTaken, ErrAssetNameTaken, ErrLabelTaken, ErrAddressTaken,
+	ErrContactExists, ErrAlertResolved, ErrRoleGranted, ErrUserNotFound, ErrInvalidSecret,
+	ErrAddressNotOwned, ErrAddressNotBlocked, ErrLabelNotFound, ErrAlertNotFound, ErrTransferNotFound,
+	ErrRoleNotGranted, ErrTransientRequired, ErrAmountInvalid, ErrAmountOverflow, ErrAmountPrecision,
+	ErrUnbalancedEntry, ErrRequestReused, ErrMemoKeyRequired, ErrPaymentRequestNotFound, ErrPaymentRequestClosed,
+	ErrTransferNotPending, ErrNotAllowlisted, ErrHoldNotFound, ErrHoldClosed,
+	ErrEscrowNotFound, ErrEscrowClosed, ErrNameTaken, ErrNameNotFound, ErrAddressBlocked,
+}
+
+// CatalogResponse the error catalog sorted by error code
+type CatalogResponse struct {
+	Errors []ServiceStatus `json:"errors"`
+}
+
+// GetErrorCatalog get the error codes with their status code and default message
+func GetErrorCatalog(c router.Context) (interface{}, error) {
+	responseBody := CatalogResponse{Errors: []ServiceStatus{}}
+	for _, err := range Catalog {
+		responseBody.Errors = append(responseBody.Errors, NewErrorStatus(err))
+	}
+	sort.Slice(responseBody.Errors, func(i, j int) bool {
+		return responseBody.Errors[i].ErrorCode < responseBody.Errors[j].ErrorCode
+	})
+
+	// return the response
+	return responseBody, nil
+}
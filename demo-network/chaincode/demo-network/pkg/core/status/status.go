@@ -3,6 +3,7 @@ package status
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	validation "github.com/go-ozzo/ozzo-validation"
@@ -10,42 +11,47 @@ import (
 
 // ErrInternal represents internal server error.
 var ErrInternal = ErrServiceStatus{
-	ServiceStatus{Code: http.StatusInternalServerError, Message: "Internal Server Error"},
+	ServiceStatus: ServiceStatus{Code: http.StatusInternalServerError, ErrorCode: "INTERNAL", Message: "Internal Server Error"},
 }
 
 // ErrNotFound represents an error when a domain artifact was not found.
 var ErrNotFound = ErrServiceStatus{
-	ServiceStatus{Code: http.StatusNotFound, Message: "Not Found"},
+	ServiceStatus: ServiceStatus{Code: http.StatusNotFound, ErrorCode: "NOT_FOUND", Message: "Not Found"},
 }
 
 // ErrBadRequest represents an invalid request error.
 var ErrBadRequest = ErrServiceStatus{
-	ServiceStatus{Code: http.StatusBadRequest, Message: "Bad Request"},
+	ServiceStatus: ServiceStatus{Code: http.StatusBadRequest, ErrorCode: "BAD_REQUEST", Message: "Bad Request"},
 }
 
 // ErrUnauhtorized represents an unauthorized request error.
 var ErrUnauhtorized = ErrServiceStatus{
-	ServiceStatus{Code: http.StatusUnauthorized, Message: "Unauthorized"},
+	ServiceStatus: ServiceStatus{Code: http.StatusUnauthorized, ErrorCode: "UNAUTHORIZED", Message: "Unauthorized"},
+}
+
+// ErrForbidden represents a request which the invoker is not allowed to make.
+var ErrForbidden = ErrServiceStatus{
+	ServiceStatus: ServiceStatus{Code: http.StatusForbidden, ErrorCode: "FORBIDDEN", Message: "Forbidden"},
 }
 
 // ErrNotImplemented represents an unauthorized request error.
 var ErrNotImplemented = ErrServiceStatus{
-	ServiceStatus{Code: http.StatusNotImplemented, Message: "Not Implemented"},
+	ServiceStatus: ServiceStatus{Code: http.StatusNotImplemented, ErrorCode: "NOT_IMPLEMENTED", Message: "Not Implemented"},
 }
 
 // ErrContentTypeNotSupported represents unsupported media type.
 var ErrContentTypeNotSupported = ErrServiceStatus{
-	ServiceStatus{Code: http.StatusUnsupportedMediaType, Message: "Unsupported Media Type"},
+	ServiceStatus: ServiceStatus{Code: http.StatusUnsupportedMediaType, ErrorCode: "UNSUPPORTED_MEDIA_TYPE", Message: "Unsupported Media Type"},
 }
 
 // ErrStatusConflict represents conflict because of inconsistent or duplicated info.
 var ErrStatusConflict = ErrServiceStatus{
-	ServiceStatus{Code: http.StatusConflict, Message: "Conflict because of inconsistent or duplicated info"},
+	ServiceStatus: ServiceStatus{Code: http.StatusConflict, ErrorCode: "CONFLICT", Message: "Conflict because of inconsistent or duplicated info"},
 }
 
 // ErrStatusUnprocessableEntity represents conflict because of inconsistent or duplicated info.
 var ErrStatusUnprocessableEntity = ErrServiceStatus{
-	ServiceStatus{Code: http.StatusUnprocessableEntity, Message: "The entered data is invalid."},
+	ServiceStatus: ServiceStatus{Code: http.StatusUnprocessableEntity, ErrorCode: "VALIDATION_FAILED", Message: "The entered data is invalid."},
 }
 
 // Success represents a generic success.
@@ -53,9 +59,10 @@ var Success = ServiceStatus{Code: http.StatusOK, Message: "OK"}
 
 // ServiceStatus captures basic information about a status construct.
 type ServiceStatus struct {
-	Code    int    `json:"code,omitempty"`
-	Message string `json:"msg"`
-	Details []*Dtl `json:"details,omitempty"`
+	Code      int    `json:"code,omitempty"`
+	ErrorCode string `json:"error_code,omitempty"`
+	Message   string `json:"msg"`
+	Details   []*Dtl `json:"details,omitempty"`
 }
 
 // Dtl captures basic information about a status construct.
@@ -67,27 +74,50 @@ type Dtl struct {
 // ErrServiceStatus captures basic information about an error.
 type ErrServiceStatus struct {
 	ServiceStatus
+	format string
+	args   []interface{}
 }
 
 // WithMessage returns an error status with given message.
 func (e ErrServiceStatus) WithMessage(msg string) ErrServiceStatus {
-	return ErrServiceStatus{ServiceStatus{Code: e.Code, Message: msg}}
+	return ErrServiceStatus{ServiceStatus: ServiceStatus{Code: e.Code, ErrorCode: e.ErrorCode, Message: msg}}
+}
+
+// WithMessagef returns an error status with the formatted message. The format is kept so that
+// the message can be localized
+func (e ErrServiceStatus) WithMessagef(format string, args ...interface{}) ErrServiceStatus {
+	errSvc := e.WithMessage(fmt.Sprintf(format, args...))
+	errSvc.format, errSvc.args = format, args
+	return errSvc
 }
 
 // WithError returns an error status with given err.Error().
 func (e ErrServiceStatus) WithError(err error) ErrServiceStatus {
-	return ErrServiceStatus{ServiceStatus{Code: e.Code, Message: err.Error()}}
+	return ErrServiceStatus{ServiceStatus: ServiceStatus{Code: e.Code, ErrorCode: e.ErrorCode, Message: err.Error()}}
 }
 
 // WithValidationError returns an error status with given err.Error().
 func (e ErrServiceStatus) WithValidationError(err validation.Errors) ErrServiceStatus {
-	errSvc := ErrServiceStatus{ServiceStatus{Code: e.Code, Message: e.Message, Details: nil}}
+	errSvc := ErrServiceStatus{ServiceStatus: ServiceStatus{Code: e.Code, ErrorCode: e.ErrorCode, Message: e.Message, Details: nil}}
 	for key, msg := range err {
 		errSvc.AddDtl(key, msg.Error())
 	}
 	return errSvc
 }
 
+// Localize returns the error status with the message and the details translated. The error code
+// and the detail keys stay the same
+func (e ErrServiceStatus) Localize(translate func(string) string) ErrServiceStatus {
+	errSvc := ErrServiceStatus{ServiceStatus: ServiceStatus{Code: e.Code, ErrorCode: e.ErrorCode, Message: translate(e.Message)}, format: e.format, args: e.args}
+	if e.format != "" {
+		errSvc.Message = fmt.Sprintf(translate(e.format), e.args...)
+	}
+	for _, d := range e.Details {
+		errSvc.AddDtl(d.Key, translate(d.Message))
+	}
+	return errSvc
+}
+
 // AddDtlMsg returns an error status with given message.
 func (e *ErrServiceStatus) AddDtlMsg(msgs ...string) {
 	if e.Details == nil {
@@ -110,12 +140,12 @@ func (e *ErrServiceStatus) AddDtl(key, msg string) {
 
 // New returns a new status with given status instance.
 func New(ss ServiceStatus) ServiceStatus {
-	return ServiceStatus{ss.Code, ss.Message, nil}
+	return ServiceStatus{Code: ss.Code, ErrorCode: ss.ErrorCode, Message: ss.Message}
 }
 
 // NewErrorStatus returns a new status with given status instance.
 func NewErrorStatus(err ErrServiceStatus) ServiceStatus {
-	return ServiceStatus{err.Code, err.Message, nil}
+	return ServiceStatus{Code: err.Code, ErrorCode: err.ErrorCode, Message: err.Message}
 }
 
 // NewUserDefined returns a new status with given code and message.
@@ -123,6 +153,19 @@ func NewUserDefined(code int, msg string) ServiceStatus {
 	return ServiceStatus{Code: code, Message: msg}
 }
 
+// Is reports whether the target is the same error status. The error codes are compared so that
+// errors.Is matches the sentinel values whatever the message
+func (e ErrServiceStatus) Is(target error) bool {
+	t, ok := target.(ErrServiceStatus)
+	if !ok {
+		return false
+	}
+	if e.ErrorCode == "" && t.ErrorCode == "" {
+		return e.Code == t.Code
+	}
+	return e.ErrorCode == t.ErrorCode
+}
+
 // Error returns the error object
 func (e ErrServiceStatus) Error() string {
 	if errB, err := json.Marshal(&e); err == nil {
@@ -2,10 +2,14 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"time"
 
 	"github.com/chaincode/demo-network/pkg/core/status"
 
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/s7techlab/cckit/router"
 )
 
@@ -38,19 +42,196 @@ const (
 	DocTypeAsset        string = "assets"            // For assets
 	DocTypeTransaction  string = "transactions"      // For transactions
 	DocTypeAddressBook  string = "address_book"      // For address_book
+	DocTypeBlocked      string = "blocked_addresses" // For blocked_addresses
+	DocTypeAlert        string = "compliance_alerts" // For compliance_alerts
 	WalletCoinSymbol    string = "ABTC"              // Symbol for Wallet Coins
 	AssetTxnType        string = "asset"             // To define asset related transactions
 	CoinTxnType         string = "coin"              // To define coin related transactions
 	AssetCreatedTxn     string = "asset_created"     // To define asset_created related transactions
 	AssetTransferredTxn string = "asset_transferred" // To define asset_transferred related transactions
+	InternalMoveTxn     string = "internal_move"     // To define moves between the addresses of same user
 	Send                int32  = 1                   // Flag to define send transaction
 	Receive             int32  = 2                   // Flag to define receive transaction
+	InitialBalance      int64  = 10000               // Coins of a new user
 	AddAssetFee         int64  = 880                 // Defined fee to add asset
 	TransferAssetFee    int64  = 3                   // Defined fee to transfer asset
+	WalletCoinScale     int    = 8                   // Decimals of the wallet coins
+	AssetScale          int    = 0                   // Decimals of the assets
+	AlertBlockedAddress string = "blocked_address"   // Alert raised when a blocked address is involved
+	AlertStatusOpen     string = "open"              // Alert which is not yet reviewed
 )
 
-// Get Finds the record by ID
-func Get(c router.Context, query string, message string) ([]byte, string, error) {
+// Constants Double-entry journal of the value movements
+const (
+	DocTypeJournalEntry string = "journal_entries" // For journal_entries
+	FeeAccount          string = "@fees"           // System account which receives the fees
+	IssuanceAccount     string = "@issuance"       // System account which issues the initial balances and the assets
+	ConfidentialAccount string = "@confidential/"  // Prefix of the system accounts holding the confidential balances of a collection
+	InitialBalanceTxn   string = "initial_balance" // To define the coins of a new user
+)
+
+// Constants Payment requests and their states
+const (
+	DocTypePaymentRequest string = "payment_requests" // For payment_requests
+	PaymentPending        string = "pending"          // Request which has not been paid yet
+	PaymentPartiallyPaid  string = "partially_paid"   // Request of which a part has been paid
+	PaymentSettled        string = "settled"          // Request which has been paid in full
+	PaymentDeclined       string = "declined"         // Request which the payer has declined
+	PaymentExpired        string = "expired"          // Request which was not paid before its expiry
+)
+
+// Constants Incoming asset transfers which wait for the acceptance of the receiver
+const (
+	DocTypePendingTransfer string = "pending_transfers" // For pending_transfers
+	PendingAccount         string = "@pending/"         // Prefix of the system accounts holding the pending assets of an address
+	AssetReturnedTxn       string = "asset_returned"    // To define the assets returned to the sender
+	PendingTransferExpiry  int64  = 604800              // Seconds after which a pending transfer can be refunded
+	TransferPending        string = "pending"           // Transfer which the receiver has not accepted yet
+	TransferAccepted       string = "accepted"          // Transfer which the receiver has accepted
+	TransferRejected       string = "rejected"          // Transfer which the receiver has rejected
+	TransferRefunded       string = "refunded"          // Transfer which was refunded after its expiry
+	TransferExpired        string = "expired"           // Transfer which was not accepted before its expiry
+)
+
+// Constants Restricted assets of which the issuer approves each transfer
+const (
+	DocTypeAssetPolicy        string = "asset_policies"       // For asset_policies
+	DocTypeRestrictedTransfer string = "restricted_transfers" // For restricted_transfers
+	TransferApproved          string = "approved"             // Transfer which the issuer has approved
+	DocTypeAllowlist          string = "asset_allowlists"     // For asset_allowlists
+)
+
+// Constants Holds which reserve a part of the balance for a merchant
+const (
+	DocTypeHold  string = "holds"    // For holds
+	HoldActive   string = "active"   // Hold which still reserves its quantity
+	HoldCaptured string = "captured" // Hold which the merchant has captured, the rest is released
+	HoldReleased string = "released" // Hold which the merchant has released
+	HoldExpired  string = "expired"  // Hold which was not captured before its expiry
+)
+
+// Constants Escrows between a buyer and a seller and their states
+const (
+	DocTypeEscrow       string = "escrows"         // For escrows
+	EscrowAccount       string = "@escrow/"        // Prefix of the system accounts holding the funds of an escrow
+	EscrowEvent         string = "escrow_status"   // Chaincode event of the changes of an escrow
+	EscrowLockedTxn     string = "escrow_locked"   // To define the funds locked into an escrow
+	EscrowReleasedTxn   string = "escrow_released" // To define the funds paid out of an escrow
+	EscrowRefundedTxn   string = "escrow_refunded" // To define the funds refunded out of an expired escrow
+	EscrowFunded        string = "funded"          // Escrow which holds the funds of the buyer
+	EscrowConfirmed     string = "confirmed"       // Action of a party confirming the trade
+	EscrowDisputed      string = "disputed"        // Escrow which waits for the decision of the arbiter
+	EscrowReleased      string = "released"        // Escrow paid to the seller after both parties confirmed
+	EscrowResolved      string = "resolved"        // Escrow split by the arbiter
+	EscrowRefunded      string = "refunded"        // Escrow refunded to the buyer after its expiry
+	EscrowExpired       string = "expired"         // Escrow which was not released before its expiry or decided before the end of its dispute
+	EscrowDisputePeriod int64  = 1209600           // Seconds the arbiter has to decide a dispute before the buyer can be refunded
+)
+
+// Constants Names registered for the addresses
+const (
+	DocTypeAddressName     string = "address_names" // For address_names
+	NameRegistrationPeriod int64  = 31536000        // Seconds for which a name is registered or renewed
+)
+
+// Constants Transaction monitoring rules and their default values for a code without its own rules
+const (
+	DocTypeMonitoringRules   string = "monitoring_rules" // For monitoring_rules
+	AlertLargeTransfer       string = "large_transfer"   // Alert raised when a transfer is above the threshold
+	AlertStructuring         string = "structuring"      // Alert raised for many small transfers in a short window
+	AlertNewCounterparty     string = "new_counterparty" // Alert raised for a large first transfer to a counterparty
+	AlertStatusResolved      string = "resolved"         // Alert which has been reviewed by an auditor
+	LargeTransferThreshold   int64  = 5000               // Default quantity above which a transfer is large
+	StructuringMaxQuantity   int64  = 100                // Default quantity up to which a transfer is small
+	StructuringCount         int    = 5                  // Default number of small transfers which raise an alert
+	StructuringWindow        int64  = 3600               // Default window in seconds to count small transfers
+	NewCounterpartyThreshold int64  = 1000               // Default quantity above which a first transfer is flagged
+)
+
+// Constants Address book import and export formats
+const (
+	ContactsFormatCSV  string = "csv"  // Contacts as CSV with address and label columns
+	ContactsFormatJSON string = "json" // Contacts as JSON array of address and label objects
+)
+
+// Constants Private data collections and the transient map entries
+const (
+	CollectionUserPrivate string = "collectionUserPrivate" // Collection holding the secrets and identities of users
+	DocTypeUserPrivate    string = "users_private"         // For users_private
+	TransientUserKey      string = "user"                  // Transient map entry carrying the secret and identity of user
+	SecretLength          int    = 300                     // Length of the secret part of the login secret
+)
+
+// Constants Confidential transfers between the organizations sharing a collection
+const (
+	DocTypeConfidentialBalance  string = "confidential_balances"  // For confidential_balances
+	DocTypeConfidentialTransfer string = "confidential_transfers" // For confidential_transfers
+	DocTypeCommitment           string = "transfer_commitments"   // For transfer_commitments
+	ConfidentialDepositTxn      string = "confidential_deposit"   // To define moves into the confidential balance
+	ConfidentialWithdrawTxn     string = "confidential_withdraw"  // To define moves out of the confidential balance
+	TransientTransferKey        string = "transfer"               // Transient map entry carrying the confidential transfer
+	TransientMemoKey            string = "memo"                   // Transient map entry carrying the private memo of a transfer
+	SaltLength                  int    = 16                       // Minimum length of the salt of a commitment
+)
+
+// ConfidentialCollections the member organizations of the collections which hold the confidential
+// balances, as in the member policies of collections_config.json
+var ConfidentialCollections = map[string][]string{
+	"collectionConfidentialOrg1MSP":        {"Org1MSP"},
+	"collectionConfidentialOrg1MSPOrg2MSP": {"Org1MSP", "Org2MSP"},
+}
+
+// Constants Key-level endorsement policies
+const (
+	DocTypeEndorsement string = "endorsement_policies" // For endorsement_policies
+)
+
+// Constants Roles which can be granted to the identities
+const (
+	DocTypeRoleGrant  string = "role_grants" // For role_grants
+	DocTypeAdminAudit string = "admin_audit" // For admin_audit
+	RoleAdmin         string = "admin"       // Grants and revokes the roles, changes the endorsement policies
+	RoleIssuer        string = "issuer"      // Issues and manages the assets
+	RoleCompliance    string = "compliance"  // Maintains the blocked list and the monitoring rules
+	RoleAuditor       string = "auditor"     // Reviews the compliance alerts and the audit trail
+	RoleOperator      string = "operator"    // Runs the data migrations
+	RoleGranted       string = "grant"       // Audit action of granting a role
+	RoleRevoked       string = "revoke"      // Audit action of revoking a role
+)
+
+// Constants Attributes of the certificates registered by the Fabric CA
+const (
+	AttrRole         string = "wallet.role"     // Role of the user in the wallet
+	AttrKYCLevel     string = "wallet.kycLevel" // KYC level of the user, a number
+	AttrIssuer       string = "issuer"          // Whether the user may issue assets
+	AttrAffiliation  string = "hf.Affiliation"  // Affiliation of the user, added by the CA
+	AttrEnrollmentID string = "hf.EnrollmentID" // Enrollment ID of the user, added by the CA
+	AttrType         string = "hf.Type"         // Type of the identity, added by the CA
+	ClaimsKey        string = "auth.claims"     // Context key of the claims of the invoker
+)
+
+// Constants for the localization of the messages
+const (
+	DefaultLocale      string = "en"          // Locale of the messages in the code
+	TransientLocaleKey string = "locale"      // Transient map entry of the requested locale
+	LocaleKey          string = "i18n.locale" // Context key of the locale of the invocation
+)
+
+// Constants for the fields which the client app adds to every payload
+const (
+	PayloadUserKey      string = "user"       // Identity which the client app invokes with
+	PayloadLocaleKey    string = "locale"     // Requested locale of the messages
+	PayloadRequestIDKey string = "request_id" // Client request ID which makes the invocation safe to retry
+)
+
+// Constants Idempotency of the retried invocations
+const (
+	DocTypeRequest        string = "request_records" // For request_records
+	TransientRequestIDKey string = "request_id"      // Transient map entry of the client request ID
+)
+
+// Get Finds the record by ID, the message with its args is returned when no record is found
+func Get(c router.Context, query string, message string, args ...interface{}) ([]byte, string, error) {
 	stub := c.Stub()
 	// excecute the query
 	resultsIterator, err := stub.GetQueryResult(query)
@@ -63,7 +244,7 @@ func Get(c router.Context, query string, message string) ([]byte, string, error)
 
 	// query has returned the results?
 	if !resultsIterator.HasNext() {
-		return nil, "", status.ErrNotFound.WithMessage(message)
+		return nil, "", status.ErrNotFound.WithMessagef(message, args...)
 	}
 
 	// fetch the data and marshal it into struct
@@ -74,3 +255,91 @@ func Get(c router.Context, query string, message string) ([]byte, string, error)
 
 	return queryResponse.Value, queryResponse.Key, nil
 }
+
+// GetAll Finds all the records matching the query
+func GetAll(c router.Context, query string) ([]*queryresult.KV, error) {
+	stub := c.Stub()
+	// excecute the query
+	resultsIterator, err := stub.GetQueryResult(query)
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+	defer resultsIterator.Close()
+
+	var results []*queryresult.KV
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		results = append(results, queryResponse)
+	}
+
+	return results, nil
+}
+
+// GetTransient parses the entry of key in the transient map into target
+func GetTransient(c router.Context, key string, target interface{}) error {
+	transient, err := c.Stub().GetTransient()
+	if err != nil {
+		return status.ErrInternal.WithError(err)
+	}
+
+	value, ok := transient[key]
+	if !ok || len(value) == 0 {
+		return status.ErrTransientRequired.WithMessagef("Transient data %s is required!", key)
+	}
+
+	err = json.Unmarshal(value, target)
+	if err != nil {
+		return status.ErrBadRequest.WithError(err)
+	}
+	return nil
+}
+
+// PutPrivate saves the value under key in the private data collection
+func PutPrivate(c router.Context, collection string, key string, value interface{}) error {
+	valueAsBytes, err := json.Marshal(value)
+	if err != nil {
+		return status.ErrInternal.WithError(err)
+	}
+
+	err = c.Stub().PutPrivateData(collection, key, valueAsBytes)
+	if err != nil {
+		return status.ErrInternal.WithError(err)
+	}
+	return nil
+}
+
+// GetPrivate finds the value of key in the private data collection and parses it into target
+func GetPrivate(c router.Context, collection string, key string, target interface{}) error {
+	valueAsBytes, err := c.Stub().GetPrivateData(collection, key)
+	if err != nil {
+		return status.ErrInternal.WithError(err)
+	}
+	if valueAsBytes == nil {
+		return status.ErrNotFound.WithMessagef("Private data %s does not exist!", key)
+	}
+
+	err = json.Unmarshal(valueAsBytes, target)
+	if err != nil {
+		return status.ErrInternal.WithError(err)
+	}
+	return nil
+}
+
+// Hash returns the hex encoded SHA-256 hash of value
+func Hash(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
+
+// TxTime returns the timestamp of the transaction in UTC. Every endorser sees the same timestamp,
+// unlike the clock of the peer, so the expiries are decided the same on every peer
+func TxTime(c router.Context) (time.Time, error) {
+	txTime, err := c.Time()
+	if err != nil {
+		return time.Time{}, status.ErrInternal.WithError(err)
+	}
+	return txTime.UTC(), nil
+}
@@ -3,14 +3,49 @@ package utils
 
 // Constants Order Validation Error messages
 const (
-	IDRequired       string = "ID is required."
-	UserIDRequired   string = "User ID is required."
-	CodeRequired     string = "Please enter code."
-	LabelRequired    string = "Please enter label."
-	QuantityRequired string = "Please enter Quantity."
-	NameRequired     string = "Please enter Name."
-	EmailRequired    string = "Please enter email."
-	PhoneRequired    string = "Please enter phone."
-	AddressRequired  string = "Please enter Address."
-	SecretRequired   string = "Secret key is required."
+	IDRequired           string = "ID is required."
+	UserIDRequired       string = "User ID is required."
+	CodeRequired         string = "Please enter code."
+	LabelRequired        string = "Please enter label."
+	QuantityRequired     string = "Please enter Quantity."
+	NameRequired         string = "Please enter Name."
+	EmailRequired        string = "Please enter email."
+	PhoneRequired        string = "Please enter phone."
+	AddressRequired      string = "Please enter Address."
+	SecretRequired       string = "Secret key is required."
+	SecretInvalid        string = "Secret key is invalid."
+	IdentityRequired     string = "Identity is required."
+	ReasonRequired       string = "Please enter reason."
+	AddressesEmpty       string = "Please enter at least one address."
+	AlertIDRequired      string = "Alert ID is required."
+	ResolutionRequired   string = "Please enter resolution."
+	FormatRequired       string = "Please enter format."
+	FormatInvalid        string = "Format must be csv or json."
+	DataRequired         string = "Please enter data."
+	PositiveRequired     string = "Value must be greater than zero."
+	CollectionRequired   string = "Please enter collection."
+	ReferenceRequired    string = "Reference is required."
+	SaltInvalid          string = "Salt must be at least 16 characters."
+	KeyRequired          string = "Key is required."
+	OrgsRequired         string = "Please enter at least one organization."
+	MSPIDRequired        string = "MSP ID is required."
+	UserRequired         string = "User is required."
+	RoleRequired         string = "Please enter role."
+	RoleInvalid          string = "Role must be admin, issuer, compliance, auditor or operator."
+	AmountTooLarge       string = "Value must not be greater than 1000000000."
+	CodeInvalid          string = "Code must be 2 to 10 uppercase letters or digits, starting with a letter."
+	LabelInvalid         string = "Label must be at most 50 letters, digits, spaces or . _ ' - characters."
+	AddressInvalid       string = "Address must be 26 to 64 letters or digits."
+	MemoInvalid          string = "Memo must be at most 140 characters."
+	MemoRequired         string = "Please enter memo."
+	EncryptedMemoInvalid string = "Encrypted memo must be a base64 ciphertext of at most 1024 characters."
+	PublicKeyRequired    string = "Public key is required."
+	PaymentIDRequired    string = "Payment request ID is required."
+	ExpiryInvalid        string = "Expiry must be an RFC3339 time."
+	StatusInvalid        string = "Status must be pending, partially_paid, settled, declined or expired."
+	TransferIDRequired   string = "Transfer ID is required."
+	HoldIDRequired       string = "Hold ID is required."
+	EscrowIDRequired     string = "Escrow ID is required."
+	AddressNameInvalid   string = "Name must be dot-separated parts of lowercase letters, digits or -, such as alice.wallet."
+	RecipientInvalid     string = "Recipient must be an address or a registered name."
 )
@@ -0,0 +1,156 @@
+// Package users Wallet address related functions
+package users
+
+import (
+	"encoding/json"
+
+	"github.com/chaincode/demo-network/pkg/core/auth"
+	"github.com/chaincode/demo-network/pkg/core/status"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// RenameAddress change the label of the address of user
+func RenameAddress(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(Address)
+
+	user, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	i, ok := findAddress(user.UserAddresses, data.Value)
+	if !ok {
+		return nil, status.ErrAddressNotOwned.WithMessagef("Address %s does not belong to you!", data.Value)
+	}
+
+	if address, ok := findAddressByLabel(user.UserAddresses, data.Label); ok && address.Value != data.Value {
+		return nil, status.ErrLabelTaken.WithMessagef("This label %s has already been taken!", data.Label)
+	}
+
+	// past transactions keep the label which the address had at that time
+	user.UserAddresses[i].Label = data.Label
+
+	// Save the data and return the response
+	return userResponse(data.UserID, user), c.State().Put(data.UserID, user)
+}
+
+// RetireAddress stop the address of user from receiving funds
+func RetireAddress(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(AddressValue)
+
+	user, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	i, ok := findAddress(user.UserAddresses, data.Value)
+	if !ok {
+		return nil, status.ErrAddressNotOwned.WithMessagef("Address %s does not belong to you!", data.Value)
+	}
+	if user.UserAddresses[i].Retired {
+		return nil, status.ErrAddressRetired.WithMessagef("Address %s is already retired!", data.Value)
+	}
+	if user.Address == data.Value {
+		return nil, status.ErrPrimaryAddress.WithMessagef("Primary address can't be retired, please set another primary address first!")
+	}
+
+	user.UserAddresses[i].Retired = true
+
+	// Save the data and return the response
+	return userResponse(data.UserID, user), c.State().Put(data.UserID, user)
+}
+
+// SetPrimaryAddress promote the address of user to primary
+func SetPrimaryAddress(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(AddressValue)
+
+	user, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	i, ok := findAddress(user.UserAddresses, data.Value)
+	if !ok {
+		return nil, status.ErrAddressNotOwned.WithMessagef("Address %s does not belong to you!", data.Value)
+	}
+	if user.UserAddresses[i].Retired {
+		return nil, status.ErrAddressRetired.WithMessagef("Address %s has been retired!", data.Value)
+	}
+
+	user.Address = data.Value
+
+	// Save the data and return the response
+	return userResponse(data.UserID, user), c.State().Put(data.UserID, user)
+}
+
+// getUser returns the user with given ID
+func getUser(c router.Context, userID string) (User, error) {
+	user := User{}
+	userAsBytes, err := c.Stub().GetState(userID)
+	if err != nil {
+		return user, status.ErrInternal.WithError(err)
+	}
+	if userAsBytes == nil {
+		return user, status.ErrUserNotFound.WithMessagef("User %s does not exist!", userID)
+	}
+
+	err = json.Unmarshal(userAsBytes, &user)
+	if err != nil {
+		return user, status.ErrInternal.WithError(err)
+	}
+	return user, nil
+}
+
+// authorizeUser returns the user when the invoker is the identity which created it. The user IDs
+// of the payloads are public, so they alone can't tell who acts
+func authorizeUser(c router.Context, userID string) (User, error) {
+	user, err := getUser(c, userID)
+	if err != nil {
+		return User{}, err
+	}
+	claims, err := auth.FromContext(c)
+	if err != nil {
+		return User{}, err
+	}
+	if user.OwnerID == "" || user.OwnerMSPID != claims.MSPID || user.OwnerID != claims.EnrollmentID {
+		return User{}, status.ErrForbidden.WithMessagef("You can't act as user %s!", userID)
+	}
+	return user, nil
+}
+
+// userResponse prepares the response body of user
+func userResponse(userID string, user User) UserResponse {
+	return UserResponse{ID: userID, Address: user.Address, WalletBalance: user.WalletBalance, Symbol: user.Symbol, CreatedAt: user.CreatedAt, UserAddresses: user.UserAddresses, Identity: user.Identity}
+}
+
+// findAddress returns the address with given value and its index
+func findAddress(addresses []Address, value string) (int, bool) {
+	for i := range addresses {
+		if addresses[i].Value == value {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// findAddressByLabel returns the address with given label
+func findAddressByLabel(addresses []Address, label string) (Address, bool) {
+	for i := range addresses {
+		if addresses[i].Label == label {
+			return addresses[i], true
+		}
+	}
+	return Address{}, false
+}
+
+// addressByValue returns the address with given value or an empty one
+func addressByValue(addresses []Address, value string) Address {
+	if i, ok := findAddress(addresses, value); ok {
+		return addresses[i]
+	}
+	return Address{}
+}
@@ -0,0 +1,178 @@
+// Package users Asset allowlist related functions
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// SetAllowlistMode turn on or off the allowlist of the asset, when it is on only the addresses on
+// the allowlist may receive the asset
+func SetAllowlistMode(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(AllowlistMode)
+
+	policy, err := getIssuerPolicy(c, data.UserID, data.Code)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	policy.Allowlisted = data.Enabled
+	policy.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	// Save the data and return the response
+	return policy, c.State().Put([]string{utils.DocTypeAssetPolicy, data.Code}, policy)
+}
+
+// AddAllowlistAddresses add the list of addresses to the allowlist of the asset
+func AddAllowlistAddresses(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(AllowlistAddresses)
+
+	policy, err := getIssuerPolicy(c, data.UserID, data.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	txTime, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	createdAt := txTime.Format(time.RFC3339)
+	responseBody := AllowlistResponse{Code: data.Code, Enabled: policy.Allowlisted, Addresses: []string{}}
+	// an address given twice is added once
+	added := map[string]bool{}
+	for _, address := range data.Addresses {
+		if added[address] {
+			continue
+		}
+		added[address] = true
+
+		entry := AllowlistEntry{Code: data.Code, Address: address, DocType: utils.DocTypeAllowlist, CreatedAt: createdAt}
+		err = c.State().Put([]string{utils.DocTypeAllowlist, data.Code, address}, entry)
+		if err != nil {
+			return nil, err
+		}
+		responseBody.Addresses = append(responseBody.Addresses, address)
+	}
+
+	// return the response
+	return responseBody, nil
+}
+
+// RemoveAllowlistAddresses remove the list of addresses from the allowlist of the asset, the
+// addresses which are not on the allowlist are skipped
+func RemoveAllowlistAddresses(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(AllowlistAddresses)
+
+	_, err := getIssuerPolicy(c, data.UserID, data.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	// the state read does not see the deletes of the transaction, an address given twice is removed once
+	removed := 0
+	seen := map[string]bool{}
+	for _, address := range data.Addresses {
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+
+		eligible, err := isAllowlisted(c, data.Code, address)
+		if err != nil {
+			return nil, err
+		}
+		if !eligible {
+			continue
+		}
+		err = c.State().Delete([]string{utils.DocTypeAllowlist, data.Code, address})
+		if err != nil {
+			return nil, err
+		}
+		removed++
+	}
+
+	responseBody := utils.ResponseMessage{Message: fmt.Sprintf("%d addresses have been removed from the allowlist of %s.", removed, data.Code)}
+
+	// return the response
+	return responseBody, nil
+}
+
+// GetAllowlist get the allowlist of the asset, and whether the address may receive the asset when one is given
+func GetAllowlist(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(AllowlistQuery)
+
+	policy, err := getAssetPolicy(c, data.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	queryString := fmt.Sprintf("{\"selector\":{\"code\":\"%s\",\"doc_type\":\"%s\"}}", data.Code, utils.DocTypeAllowlist)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := AllowlistResponse{Code: data.Code, Enabled: policy.Allowlisted, Addresses: []string{}}
+	for _, result := range results {
+		entry := AllowlistEntry{}
+		err = json.Unmarshal(result.Value, &entry)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		responseBody.Addresses = append(responseBody.Addresses, entry.Address)
+	}
+	sort.Strings(responseBody.Addresses)
+
+	if data.Address != "" {
+		eligible := !policy.Allowlisted
+		for _, address := range responseBody.Addresses {
+			if address == data.Address {
+				eligible = true
+			}
+		}
+		responseBody.Address = data.Address
+		responseBody.Eligible = &eligible
+	}
+
+	// return the response
+	return responseBody, nil
+}
+
+// getIssuerPolicy returns the policy of the asset which only its issuer may change
+func getIssuerPolicy(c router.Context, userID string, code string) (AssetPolicy, error) {
+	_, err := authorizeUser(c, userID)
+	if err != nil {
+		return AssetPolicy{}, err
+	}
+	policy, err := getAssetPolicy(c, code)
+	if err != nil {
+		return AssetPolicy{}, err
+	}
+	if policy.IssuerID != userID {
+		return AssetPolicy{}, status.ErrForbidden.WithMessagef("Only the issuer of %s can manage its allowlist!", code)
+	}
+	return policy, nil
+}
+
+// isAllowlisted checks whether the address is on the allowlist of the asset
+func isAllowlisted(c router.Context, code string, address string) (bool, error) {
+	exists, err := c.State().Exists([]string{utils.DocTypeAllowlist, code, address})
+	if err != nil {
+		return false, status.ErrInternal.WithError(err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,103 @@
+// Package users Amount migration related functions
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// MigrateAmounts rewrite the integer balances and quantities which were stored before the
+// amounts had decimals as decimal strings with the scale of their code
+func MigrateAmounts(c router.Context) (interface{}, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"wallet_balance\":{\"$type\":\"number\"},\"doc_type\":\"%s\"}}", utils.DocTypeUser)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		user := User{}
+		err = json.Unmarshal(result.Value, &user)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+
+		user.WalletBalance, err = user.WalletBalance.For(utils.WalletCoinSymbol)
+		if err != nil {
+			return nil, err
+		}
+		for i := range user.UserAddresses {
+			user.UserAddresses[i].Balance, err = user.UserAddresses[i].Balance.For(utils.WalletCoinSymbol)
+			if err != nil {
+				return nil, err
+			}
+		}
+		err = c.State().Put(result.Key, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	assets, err := migrateQuantities(c, utils.DocTypeAsset, func() quantityDoc { return &Asset{} })
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := migrateQuantities(c, utils.DocTypeTransaction, func() quantityDoc { return &Transaction{} })
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := utils.ResponseMessage{Message: fmt.Sprintf("%d users, %d assets and %d transactions have been migrated.", len(results), assets, transactions)}
+
+	// return the response
+	return responseBody, nil
+}
+
+// quantityDoc a document with the quantity of a code
+type quantityDoc interface {
+	rescale() error
+}
+
+// rescale sets the scale of the code on the quantity of the asset
+func (asset *Asset) rescale() (err error) {
+	asset.Quantity, err = asset.Quantity.For(asset.Code)
+	return err
+}
+
+// rescale sets the scale of the code on the quantity of the transaction
+func (transaction *Transaction) rescale() (err error) {
+	transaction.Quantity, err = transaction.Quantity.For(transaction.Code)
+	return err
+}
+
+// migrateQuantities rewrites the integer quantities of the documents of the type and returns
+// the number of documents migrated
+func migrateQuantities(c router.Context, docType string, newDoc func() quantityDoc) (int, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"quantity\":{\"$type\":\"number\"},\"doc_type\":\"%s\"}}", docType)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, result := range results {
+		doc := newDoc()
+		err = json.Unmarshal(result.Value, doc)
+		if err != nil {
+			return 0, status.ErrInternal.WithError(err)
+		}
+		err = doc.rescale()
+		if err != nil {
+			return 0, err
+		}
+		err = c.State().Put(result.Key, doc)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(results), nil
+}
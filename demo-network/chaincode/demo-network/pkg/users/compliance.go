@@ -0,0 +1,179 @@
+// Package users Compliance related functions
+package users
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// AddBlockedAddress add the address to the blocked list
+func AddBlockedAddress(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(BlockedAddress)
+
+	createdAt, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// set the default values for the fields
+	data.DocType = utils.DocTypeBlocked
+	data.CreatedAt = createdAt.Format(time.RFC3339)
+
+	// Save the data and return the response
+	return data, c.State().Put([]string{utils.DocTypeBlocked, data.Address}, data)
+}
+
+// ImportBlockedAddresses add the list of addresses to the blocked list
+func ImportBlockedAddresses(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(BlockedAddresses)
+
+	txTime, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	createdAt := txTime.Format(time.RFC3339)
+	responseBody := BlockedAddressesResponse{BlockedAddresses: []BlockedAddress{}}
+	for _, address := range data.Addresses {
+		if address == "" {
+			continue
+		}
+
+		blocked := BlockedAddress{Address: address, Reason: data.Reason, DocType: utils.DocTypeBlocked, CreatedAt: createdAt}
+		err := c.State().Put([]string{utils.DocTypeBlocked, address}, blocked)
+		if err != nil {
+			return nil, err
+		}
+		responseBody.BlockedAddresses = append(responseBody.BlockedAddresses, blocked)
+	}
+
+	// return the response
+	return responseBody, nil
+}
+
+// RemoveBlockedAddress remove the address from the blocked list
+func RemoveBlockedAddress(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(BlockedAddressID)
+
+	blocked, err := isBlocked(c, data.Address)
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+	if !blocked {
+		return nil, status.ErrAddressNotBlocked.WithMessagef("Address %s is not blocked!", data.Address)
+	}
+
+	responseBody := utils.ResponseMessage{Message: fmt.Sprintf("Address %s has been removed from the blocked list.", data.Address)}
+
+	// Delete the data and return the response
+	return responseBody, c.State().Delete([]string{utils.DocTypeBlocked, data.Address})
+}
+
+// ListBlockedAddresses get the all blocked addresses
+func ListBlockedAddresses(c router.Context) (interface{}, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"doc_type\":\"%s\"}}", utils.DocTypeBlocked)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := BlockedAddressesResponse{BlockedAddresses: []BlockedAddress{}}
+	for _, result := range results {
+		blocked := BlockedAddress{}
+		err = json.Unmarshal(result.Value, &blocked)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		responseBody.BlockedAddresses = append(responseBody.BlockedAddresses, blocked)
+	}
+
+	// return the response
+	return responseBody, nil
+}
+
+// isBlocked checks whether the address is on the blocked list
+func isBlocked(c router.Context, address string) (bool, error) {
+	return c.State().Exists([]string{utils.DocTypeBlocked, address})
+}
+
+// blockedAttempt the error of an invocation which was stopped by the blocked list. The compliance
+// alert has already been saved, Screened returns its response so that the alert is committed
+type blockedAttempt struct {
+	status.ErrServiceStatus
+	response ComplianceAlertResponse
+}
+
+// Screened commits the invocations which were stopped by the blocked list. The handler stops on
+// the blocked address before it writes anything else, so the compliance alert is the only write and
+// it is returned as the response. It must be the last middleware of the route
+func Screened(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+	return func(c router.Context) (interface{}, error) {
+		response, err := next(c)
+		var blocked blockedAttempt
+		if errors.As(err, &blocked) {
+			return blocked.response, nil
+		}
+		return response, err
+	}
+}
+
+// screenAddresses checks the addresses against the blocked list. The first blocked address is
+// recorded as a compliance alert and the invocation is stopped with ErrAddressBlocked, the routes
+// which screen addresses are wrapped in Screened so that the alert is committed
+func screenAddresses(c router.Context, alert ComplianceAlert, addresses ...string) error {
+	for _, address := range addresses {
+		blocked, err := isBlocked(c, address)
+		if err != nil {
+			return status.ErrInternal.WithError(err)
+		}
+		if !blocked {
+			continue
+		}
+
+		alert.AlertType = utils.AlertBlockedAddress
+		alert.Address = address
+		alert.Reason = fmt.Sprintf("Address %s is on the blocked list.", address)
+		alertID, err := raiseAlert(c, alert)
+		if err != nil {
+			return err
+		}
+
+		errStatus := status.ErrAddressBlocked.WithMessagef("Address %s is on the blocked list!", address)
+		return blockedAttempt{ErrServiceStatus: errStatus, response: ComplianceAlertResponse{ID: alertID, Blocked: true, Message: alert.Reason}}
+	}
+
+	return nil
+}
+
+// raiseAlert saves the compliance alert of given type for the current transaction
+func raiseAlert(c router.Context, alert ComplianceAlert) (string, error) {
+	alert.AlertID = c.Stub().GetTxID() + ":" + alert.AlertType
+	alert.Function = c.Path()
+	alert.Status = utils.AlertStatusOpen
+	alert.DocType = utils.DocTypeAlert
+	createdAt, err := utils.TxTime(c)
+	if err != nil {
+		return "", err
+	}
+	alert.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return alert.AlertID, c.State().Put([]string{utils.DocTypeAlert, alert.AlertID}, alert)
+}
+
+// addressValues returns the values of the given addresses
+func addressValues(addresses []Address) []string {
+	var values []string
+	for i := range addresses {
+		values = append(values, addresses[i].Value)
+	}
+	return values
+}
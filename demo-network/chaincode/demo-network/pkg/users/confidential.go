@@ -0,0 +1,425 @@
+// Package users Confidential transfer related functions
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/amount"
+	"github.com/chaincode/demo-network/pkg/core/middleware"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// DepositConfidential move coins or assets from the address of user into the confidential balance
+// of user in the collection. The quantity moved is public, the later transfers are not
+func DepositConfidential(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(ConfidentialFunds)
+
+	user, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkCollection(data.Collection, user.OwnerMSPID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = allocateBalance(&user)
+	if err != nil {
+		return nil, err
+	}
+	from, err := spendingAddress(user, data.Address)
+	if err != nil {
+		return nil, err
+	}
+	data.Quantity, err = data.Quantity.For(data.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	// the address of user may have been blocked since it was added
+	err = screenAddresses(c, ComplianceAlert{UserID: data.UserID, Code: data.Code, Quantity: data.Quantity}, user.UserAddresses[from].Value)
+	if err != nil {
+		return nil, err
+	}
+
+	// the coins and the assets reserved by the holds of the address can't be deposited
+	err = checkAvailable(c, data.UserID, user, from, data.Code, data.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	assetLabel, err := adjustHolding(c, data.UserID, &user, from, data.Code, data.Quantity.Neg())
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := getConfidentialBalance(c, data.Collection, data.UserID, data.Code)
+	if err != nil {
+		return nil, err
+	}
+	balance.Quantity, err = balance.Quantity.Add(data.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	err = putConfidentialBalance(c, balance)
+	if err != nil {
+		return nil, err
+	}
+
+	// the funds go from the address of user to the account of the collection
+	entry := JournalEntry{}
+	entry.move(data.Code, data.Quantity,
+		JournalLeg{Account: user.UserAddresses[from].Value, UserID: data.UserID, TxnType: utils.ConfidentialDepositTxn, AssetLabel: assetLabel, AddressValue: data.Collection, LabelValue: data.Collection},
+		JournalLeg{Account: utils.ConfidentialAccount + data.Collection, TxnType: utils.ConfidentialDepositTxn, AssetLabel: assetLabel})
+	err = postEntry(c, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := ResponseAddAsset{ID: c.Stub().GetTxID(), Balance: user.WalletBalance, Symbol: user.Symbol}
+
+	// Save the data and return the response
+	return responseBody, c.State().Put(data.UserID, user)
+}
+
+// WithdrawConfidential move coins or assets from the confidential balance of user in the
+// collection back to the address of user
+func WithdrawConfidential(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(ConfidentialFunds)
+
+	user, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkCollection(data.Collection, user.OwnerMSPID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = allocateBalance(&user)
+	if err != nil {
+		return nil, err
+	}
+	to, err := spendingAddress(user, data.Address)
+	if err != nil {
+		return nil, err
+	}
+	if user.UserAddresses[to].Retired {
+		return nil, status.ErrAddressRetired.WithMessagef("Address %s has been retired!", user.UserAddresses[to].Value)
+	}
+
+	data.Quantity, err = data.Quantity.For(data.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	// the address of user may have been blocked since it was added
+	err = screenAddresses(c, ComplianceAlert{UserID: data.UserID, Code: data.Code, Quantity: data.Quantity}, user.UserAddresses[to].Value)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := getConfidentialBalance(c, data.Collection, data.UserID, data.Code)
+	if err != nil {
+		return nil, err
+	}
+	if data.Quantity.Cmp(balance.Quantity) > 0 {
+		return nil, status.ErrInsufficientBalance.WithMessagef("Quantity should be less or equal to %s", balance.Quantity)
+	}
+	balance.Quantity, err = balance.Quantity.Sub(data.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	err = putConfidentialBalance(c, balance)
+	if err != nil {
+		return nil, err
+	}
+
+	assetLabel, err := adjustHolding(c, data.UserID, &user, to, data.Code, data.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	// the funds go from the account of the collection back to the address of user
+	entry := JournalEntry{}
+	entry.move(data.Code, data.Quantity,
+		JournalLeg{Account: utils.ConfidentialAccount + data.Collection, TxnType: utils.ConfidentialWithdrawTxn, AssetLabel: assetLabel},
+		JournalLeg{Account: user.UserAddresses[to].Value, UserID: data.UserID, TxnType: utils.ConfidentialWithdrawTxn, AssetLabel: assetLabel, AddressValue: data.Collection, LabelValue: data.Collection})
+	err = postEntry(c, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := ResponseAddAsset{ID: c.Stub().GetTxID(), Balance: user.WalletBalance, Symbol: user.Symbol}
+
+	// Save the data and return the response
+	return responseBody, c.State().Put(data.UserID, user)
+}
+
+// GetConfidentialBalance get the confidential balance of user in the collection, only the user can read it
+func GetConfidentialBalance(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(ConfidentialBalance)
+
+	_, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return getConfidentialBalance(c, data.Collection, data.UserID, data.Code)
+}
+
+// TransferConfidential transfer coins or assets between the confidential balances of two users.
+// The details and the balances are written to the collection shared by the organizations of
+// both users, the public ledger only holds the salted commitment of the details
+func TransferConfidential(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(ConfidentialTransfer)
+
+	// the details are passed through the transient map so that they never reach the ledger
+	details := ConfidentialTransferDetails{}
+	err := utils.GetTransient(c, utils.TransientTransferKey, &details)
+	if err != nil {
+		return nil, err
+	}
+	err = middleware.Validated(details)
+	if err != nil {
+		return nil, err
+	}
+	details.Quantity, err = details.Quantity.For(details.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	// check receiver data
+	queryRecevierString := fmt.Sprintf("{\"selector\": {\"user_addresses\": {\"$elemMatch\": {\"value\": \"%s\"}},\"doc_type\":\"%s\"}}", details.To, utils.DocTypeUser)
+	receiverData, receiverID, err := utils.Get(c, queryRecevierString, "Receiver %s does not exist!", details.To)
+	if err != nil {
+		return nil, err
+	}
+	receiver := User{}
+	err = json.Unmarshal(receiverData, &receiver)
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+	if addressByValue(receiver.UserAddresses, details.To).Retired {
+		return nil, status.ErrAddressRetired.WithMessagef("Address %s has been retired!", details.To)
+	}
+	if receiverID == details.From {
+		return nil, status.ErrSelfTransfer.WithMessagef("You can't transfer coins to yourself!")
+	}
+
+	sender, err := authorizeUser(c, details.From)
+	if err != nil {
+		return nil, err
+	}
+	// the balances and the record are only written to the peers of both parties
+	err = checkCollection(data.Collection, sender.OwnerMSPID, receiver.OwnerMSPID)
+	if err != nil {
+		return nil, err
+	}
+
+	// check both parties against the blocked list, the alert does not carry the quantity
+	alert := ComplianceAlert{UserID: details.From, Counterparty: details.To, Code: details.Code}
+	err = screenAddresses(c, alert, append([]string{details.To}, addressValues(sender.UserAddresses)...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	senderBalance, err := getConfidentialBalance(c, data.Collection, details.From, details.Code)
+	if err != nil {
+		return nil, err
+	}
+	if details.Quantity.Cmp(senderBalance.Quantity) > 0 {
+		return nil, status.ErrInsufficientBalance.WithMessagef("Quantity should be less or equal to %s", senderBalance.Quantity)
+	}
+	senderBalance.Quantity, err = senderBalance.Quantity.Sub(details.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	err = putConfidentialBalance(c, senderBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	receiverBalance, err := getConfidentialBalance(c, data.Collection, receiverID, details.Code)
+	if err != nil {
+		return nil, err
+	}
+	receiverBalance.Quantity, err = receiverBalance.Quantity.Add(details.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	err = putConfidentialBalance(c, receiverBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	txID := c.Stub().GetTxID()
+	txTime, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	createdAt := txTime.Format(time.RFC3339)
+
+	record := ConfidentialRecord{Reference: txID, ReceiverID: receiverID, Details: details, DocType: utils.DocTypeConfidentialTransfer, CreatedAt: createdAt}
+	err = utils.PutPrivate(c, data.Collection, txID, record)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := commitment(details)
+	if err != nil {
+		return nil, err
+	}
+	transferCommitment := TransferCommitment{Reference: txID, Collection: data.Collection, Commitment: hash, DocType: utils.DocTypeCommitment, CreatedAt: createdAt}
+
+	// Save the data and return the response
+	return transferCommitment, c.State().Put([]string{utils.DocTypeCommitment, txID}, transferCommitment)
+}
+
+// VerifyConfidentialTransfer check the public commitment of the confidential transfer against
+// the private record, only the members of the collection can read the record
+func VerifyConfidentialTransfer(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(CommitmentReference)
+
+	commitmentData, err := c.State().Get([]string{utils.DocTypeCommitment, data.Reference}, &TransferCommitment{})
+	if err != nil {
+		return nil, status.ErrTransferNotFound.WithMessagef("Transfer %s does not exist!", data.Reference)
+	}
+	transferCommitment := commitmentData.(TransferCommitment)
+
+	record := ConfidentialRecord{}
+	err = utils.GetPrivate(c, transferCommitment.Collection, transferCommitment.Reference, &record)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := commitment(record.Details)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := CommitmentVerification{TransferCommitment: transferCommitment, Verified: hash == transferCommitment.Commitment, Record: record}
+
+	// return the response
+	return responseBody, nil
+}
+
+// commitment returns the salted hash of the details of the confidential transfer
+func commitment(details ConfidentialTransferDetails) (string, error) {
+	detailsAsBytes, err := json.Marshal(details)
+	if err != nil {
+		return "", status.ErrInternal.WithError(err)
+	}
+	return utils.Hash(string(detailsAsBytes)), nil
+}
+
+// checkCollection checks the organizations are all members of the collection of the confidential
+// balances, the caller chooses the collection
+func checkCollection(collection string, orgs ...string) error {
+	for _, org := range orgs {
+		shared := false
+		for _, member := range utils.ConfidentialCollections[collection] {
+			if member == org {
+				shared = true
+				break
+			}
+		}
+		if !shared {
+			return status.ErrForbidden.WithMessagef("Collection %s is not shared with the organization %s!", collection, org)
+		}
+	}
+	return nil
+}
+
+// getConfidentialBalance returns the confidential balance of user for code in the collection
+func getConfidentialBalance(c router.Context, collection string, userID string, code string) (ConfidentialBalance, error) {
+	balance := ConfidentialBalance{UserID: userID, Collection: collection, Code: code, DocType: utils.DocTypeConfidentialBalance}
+	key, err := c.Stub().CreateCompositeKey(utils.DocTypeConfidentialBalance, []string{userID, code})
+	if err != nil {
+		return balance, status.ErrInternal.WithError(err)
+	}
+
+	balanceAsBytes, err := c.Stub().GetPrivateData(collection, key)
+	if err != nil {
+		return balance, status.ErrInternal.WithError(err)
+	}
+	if balanceAsBytes == nil {
+		return balance, nil
+	}
+
+	err = json.Unmarshal(balanceAsBytes, &balance)
+	if err != nil {
+		return balance, status.ErrInternal.WithError(err)
+	}
+	return balance, nil
+}
+
+// putConfidentialBalance saves the confidential balance in its collection
+func putConfidentialBalance(c router.Context, balance ConfidentialBalance) error {
+	key, err := c.Stub().CreateCompositeKey(utils.DocTypeConfidentialBalance, []string{balance.UserID, balance.Code})
+	if err != nil {
+		return status.ErrInternal.WithError(err)
+	}
+	return utils.PutPrivate(c, balance.Collection, key, balance)
+}
+
+// adjustHolding changes the coins or the asset of code held by the address of user by quantity
+// and returns the label of the asset. The user document is saved by the caller
+func adjustHolding(c router.Context, userID string, user *User, address int, code string, quantity amount.Amount) (string, error) {
+	value := user.UserAddresses[address].Value
+
+	if code == utils.WalletCoinSymbol {
+		if user.UserAddresses[address].Balance.Cmp(quantity.Neg()) < 0 {
+			return "", status.ErrInsufficientBalance.WithMessagef("Quantity should be less or equal to %s", user.UserAddresses[address].Balance)
+		}
+		return "", credit(user, address, quantity)
+	}
+
+	assetData, assetKey, _ := getAddressAsset(c, userID, value, code)
+	asset := Asset{UserID: userID, Address: value, Code: code, DocType: utils.DocTypeAsset}
+	if assetData == nil {
+		// the asset is new to the address, take the label from the asset of any holder
+		queryString := fmt.Sprintf("{\"selector\":{\"code\":\"%s\",\"doc_type\":\"%s\"}}", code, utils.DocTypeAsset)
+		anyAssetData, _, err := utils.Get(c, queryString, "Symbol %s does not exist!", code)
+		if anyAssetData == nil {
+			return "", err
+		}
+		anyAsset := Asset{}
+		err = json.Unmarshal(anyAssetData, &anyAsset)
+		if err != nil {
+			return "", status.ErrInternal.WithError(err)
+		}
+		asset.Label = anyAsset.Label
+		assetKey = c.Stub().GetTxID() + strconv.Itoa(2)
+	} else {
+		err := json.Unmarshal(assetData, &asset)
+		if err != nil {
+			return "", status.ErrInternal.WithError(err)
+		}
+	}
+
+	if asset.Quantity.Cmp(quantity.Neg()) < 0 {
+		return "", status.ErrInsufficientAsset.WithMessagef("Quantity should be less or equal to %s", asset.Quantity)
+	}
+	sum, err := asset.Quantity.Add(quantity)
+	if err != nil {
+		return "", err
+	}
+	asset.Quantity = sum
+	err = c.State().Put(assetKey, asset)
+	if err != nil {
+		return "", err
+	}
+	if assetData == nil {
+		return asset.Label, endorseHolding(c, assetKey, code)
+	}
+	return asset.Label, nil
+}
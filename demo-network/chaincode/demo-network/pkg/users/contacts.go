@@ -0,0 +1,275 @@
+// Package users Address book related functions
+package users
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chaincode/demo-network/pkg/core/middleware"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// CreateContact add the contact into the address book of user
+func CreateContact(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(Contact)
+
+	contactData, _, _ := getContact(c, data.UserID, data.Address)
+	if contactData != nil {
+		return nil, status.ErrContactExists.WithMessagef("Address %s already exists in your address book!", data.Address)
+	}
+
+	labelData, _, _ := getContactByLabel(c, data.UserID, data.Label)
+	if labelData != nil {
+		return nil, status.ErrLabelTaken.WithMessagef("This label %s has already been taken!", data.Label)
+	}
+
+	contact := AddressBook{UserID: data.UserID, Address: data.Address, Label: data.Label, DocType: utils.DocTypeAddressBook}
+
+	// Save the data and return the response
+	return contact, c.State().Put(c.Stub().GetTxID(), contact)
+}
+
+// UpdateContact rename the contact in the address book of user
+func UpdateContact(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(Contact)
+
+	contactData, contactKey, err := getContact(c, data.UserID, data.Address)
+	if contactData == nil {
+		return nil, err
+	}
+
+	contact := AddressBook{}
+	err = json.Unmarshal(contactData, &contact)
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+
+	if contact.Label != data.Label {
+		labelData, _, _ := getContactByLabel(c, data.UserID, data.Label)
+		if labelData != nil {
+			return nil, status.ErrLabelTaken.WithMessagef("This label %s has already been taken!", data.Label)
+		}
+	}
+
+	contact.Label = data.Label
+
+	// Save the data and return the response
+	return contact, c.State().Put(contactKey, contact)
+}
+
+// DeleteContact remove the contact from the address book of user
+func DeleteContact(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(ContactAddress)
+
+	contactData, contactKey, err := getContact(c, data.UserID, data.Address)
+	if contactData == nil {
+		return nil, err
+	}
+
+	responseBody := utils.ResponseMessage{Message: fmt.Sprintf("Address %s has been removed from your address book.", data.Address)}
+
+	// Delete the data and return the response
+	return responseBody, c.State().Delete(contactKey)
+}
+
+// ListContacts get the all contacts in the address book of user
+func ListContacts(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(UserId)
+
+	contacts, _, err := listContacts(c, data.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// return the response
+	return ContactsResponse{Contacts: contacts}, nil
+}
+
+// ImportContacts add or rename the contacts in the address book of user from CSV or JSON
+func ImportContacts(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(ContactsImport)
+
+	var imported []Contact
+	if data.Format == utils.ContactsFormatCSV {
+		records, err := csv.NewReader(strings.NewReader(data.Data)).ReadAll()
+		if err != nil {
+			return nil, status.ErrBadRequest.WithError(err)
+		}
+		for i, record := range records {
+			if len(record) != 2 {
+				return nil, status.ErrBadRequest.WithMessagef("Line %d should have address and label!", i+1)
+			}
+			// skip the header line
+			if i == 0 && record[0] == "address" && record[1] == "label" {
+				continue
+			}
+			imported = append(imported, Contact{Address: record[0], Label: record[1]})
+		}
+	} else {
+		err := json.Unmarshal([]byte(data.Data), &imported)
+		if err != nil {
+			return nil, status.ErrBadRequest.WithError(err)
+		}
+	}
+
+	existing, existingKeys, err := listContacts(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// addresses and labels as they will be after the import
+	var addresses []string
+	keys := map[string]string{}
+	contacts := map[string]AddressBook{}
+	labels := map[string]string{}
+	for i, contact := range existing {
+		addresses = append(addresses, contact.Address)
+		keys[contact.Address] = existingKeys[i]
+		contacts[contact.Address] = contact
+		labels[contact.Label] = contact.Address
+	}
+
+	txID := c.Stub().GetTxID()
+	for i, entry := range imported {
+		entry.UserID = data.UserID
+		err = middleware.Validated(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		if address, ok := labels[entry.Label]; ok && address != entry.Address {
+			return nil, status.ErrLabelTaken.WithMessagef("This label %s has already been taken!", entry.Label)
+		}
+
+		contact, ok := contacts[entry.Address]
+		if !ok {
+			contact = AddressBook{UserID: data.UserID, Address: entry.Address, DocType: utils.DocTypeAddressBook}
+			addresses = append(addresses, entry.Address)
+			keys[entry.Address] = txID + strconv.Itoa(i+1)
+		} else if contact.Label != entry.Label {
+			delete(labels, contact.Label)
+		}
+
+		contact.Label = entry.Label
+		contacts[entry.Address] = contact
+		labels[entry.Label] = entry.Address
+
+		err = c.State().Put(keys[entry.Address], contact)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	responseBody := ContactsResponse{Contacts: []AddressBook{}}
+	for _, address := range addresses {
+		responseBody.Contacts = append(responseBody.Contacts, contacts[address])
+	}
+
+	// return the response
+	return responseBody, nil
+}
+
+// ExportContacts get the address book of user as CSV or JSON
+func ExportContacts(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(ContactsExport)
+
+	contacts, _, err := listContacts(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := []Contact{}
+	for _, contact := range contacts {
+		exported = append(exported, Contact{Address: contact.Address, Label: contact.Label})
+	}
+
+	if data.Format == utils.ContactsFormatCSV {
+		var buffer bytes.Buffer
+		writer := csv.NewWriter(&buffer)
+		writer.Write([]string{"address", "label"})
+		for _, contact := range exported {
+			writer.Write([]string{contact.Address, contact.Label})
+		}
+		writer.Flush()
+		if err = writer.Error(); err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		data.Data = buffer.String()
+	} else {
+		exportedBytes, err := json.Marshal(exported)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		data.Data = string(exportedBytes)
+	}
+
+	// return the response
+	return data, nil
+}
+
+// getContact finds the contact of address in the address book of user
+func getContact(c router.Context, userID string, address string) ([]byte, string, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"address\":\"%s\",\"doc_type\":\"%s\"}}", userID, address, utils.DocTypeAddressBook)
+	return utils.Get(c, queryString, "Address %s does not exist in your address book!", address)
+}
+
+// getContactByLabel finds the contact with label in the address book of user
+func getContactByLabel(c router.Context, userID string, label string) ([]byte, string, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"label\":\"%s\",\"doc_type\":\"%s\"}}", userID, label, utils.DocTypeAddressBook)
+	return utils.Get(c, queryString, "Label %s does not exist in your address book!", label)
+}
+
+// listContacts returns the address book of user along with the keys of the contacts
+func listContacts(c router.Context, userID string) ([]AddressBook, []string, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"doc_type\":\"%s\"}}", userID, utils.DocTypeAddressBook)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contacts := []AddressBook{}
+	var keys []string
+	for _, result := range results {
+		contact := AddressBook{}
+		err = json.Unmarshal(result.Value, &contact)
+		if err != nil {
+			return nil, nil, status.ErrInternal.WithError(err)
+		}
+		contacts = append(contacts, contact)
+		keys = append(keys, result.Key)
+	}
+	return contacts, keys, nil
+}
+
+// labelTransactions shows the current address book label of user on the transactions with its
+// contacts, the label is joined when reading so a renamed contact needs no rewrite of the journal
+func labelTransactions(c router.Context, userID string, transactions []TransactionResponse) error {
+	contacts, _, err := listContacts(c, userID)
+	if err != nil {
+		return err
+	}
+
+	labels := map[string]string{}
+	for _, contact := range contacts {
+		labels[contact.Address] = contact.Label
+	}
+	for i := range transactions {
+		if label, ok := labels[transactions[i].AddressValue]; ok {
+			transactions[i].AddressBookLabel = label
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Package users Key-level endorsement related functions
+package users
+
+import (
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim/ext/statebased"
+	"github.com/s7techlab/cckit/router"
+)
+
+// SetKeyEndorsement change the organizations which must endorse the changes of the document
+func SetKeyEndorsement(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(KeyEndorsement)
+
+	exists, err := c.State().Exists(data.Key)
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+	if !exists {
+		return nil, status.ErrNotFound.WithMessagef("Document %s does not exist!", data.Key)
+	}
+
+	return setEndorsement(c, data.Key, data.Orgs...)
+}
+
+// GetKeyEndorsement get the organizations which must endorse the changes of the document, as the
+// peers validate them. No organizations means the document only follows the endorsement policy of
+// the chaincode
+func GetKeyEndorsement(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(EndorsementKey)
+
+	policy, err := c.Stub().GetStateValidationParameter(data.Key)
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+	endorsement := KeyEndorsement{Key: data.Key, Orgs: []string{}, DocType: utils.DocTypeEndorsement}
+	if len(policy) > 0 {
+		ep, err := statebased.NewStateEP(policy)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		endorsement.Orgs = ep.ListOrgs()
+	}
+
+	// the record of the policy tells when it was last changed
+	exists, err := c.State().Exists([]string{utils.DocTypeEndorsement, data.Key})
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+	if exists {
+		recordData, err := c.State().Get([]string{utils.DocTypeEndorsement, data.Key}, &KeyEndorsement{})
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		endorsement.UpdatedAt = recordData.(KeyEndorsement).UpdatedAt
+	}
+
+	// return the response
+	return endorsement, nil
+}
+
+// setEndorsement sets the key-level endorsement policy of key, the peers of every organization
+// must then endorse the changes of the key. The policy is also recorded for the audit trail
+func setEndorsement(c router.Context, key string, orgs ...string) (KeyEndorsement, error) {
+	ep, err := statebased.NewStateEP(nil)
+	if err != nil {
+		return KeyEndorsement{}, status.ErrInternal.WithError(err)
+	}
+	err = ep.AddOrgs(statebased.RoleTypePeer, orgs...)
+	if err != nil {
+		return KeyEndorsement{}, status.ErrBadRequest.WithError(err)
+	}
+	policy, err := ep.Policy()
+	if err != nil {
+		return KeyEndorsement{}, status.ErrInternal.WithError(err)
+	}
+	err = c.Stub().SetStateValidationParameter(key, policy)
+	if err != nil {
+		return KeyEndorsement{}, status.ErrInternal.WithError(err)
+	}
+
+	updatedAt, err := utils.TxTime(c)
+	if err != nil {
+		return KeyEndorsement{}, err
+	}
+	endorsement := KeyEndorsement{Key: key, Orgs: ep.ListOrgs(), DocType: utils.DocTypeEndorsement, UpdatedAt: updatedAt.Format(time.RFC3339)}
+	return endorsement, c.State().Put([]string{utils.DocTypeEndorsement, key}, endorsement)
+}
+
+// endorseHolding requires the organization of the issuer to endorse the changes of a new holding
+// of the asset. The assets added before the issuer organization was recorded keep the policy of
+// the chaincode
+func endorseHolding(c router.Context, key string, code string) error {
+	policy, err := getAssetPolicy(c, code)
+	if err != nil {
+		return err
+	}
+	if policy.IssuerOrg == "" {
+		return nil
+	}
+	_, err = setEndorsement(c, key, policy.IssuerOrg)
+	return err
+}
+
+// creatorOrg returns the organization of the creator of the transaction
+func creatorOrg(c router.Context) (string, error) {
+	client, err := c.Client()
+	if err != nil {
+		return "", status.ErrInternal.WithError(err)
+	}
+	mspID, err := client.GetMSPID()
+	if err != nil {
+		return "", status.ErrInternal.WithError(err)
+	}
+	return mspID, nil
+}
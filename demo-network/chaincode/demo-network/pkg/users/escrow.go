@@ -0,0 +1,509 @@
+// Package users Escrow related functions
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/amount"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// CreateEscrow lock the funds of the buyer into an escrow with the seller and the optional arbiter
+func CreateEscrow(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(Escrow)
+
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, _ := time.Parse(time.RFC3339, data.ExpiresAt)
+	if !expiresAt.After(now) {
+		return nil, status.ErrStatusUnprocessableEntity.WithMessagef("Expiry %s is not in the future!", data.ExpiresAt)
+	}
+
+	// the funds are taken from the given address of buyer or else from the primary address
+	buyer, err := authorizeUser(c, data.BuyerID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = allocateBalance(&buyer)
+	if err != nil {
+		return nil, err
+	}
+	from, err := spendingAddress(buyer, data.BuyerAddress)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := findAddress(buyer.UserAddresses, data.SellerAddress); ok {
+		return nil, status.ErrSelfTransfer.WithMessagef("You can't open an escrow with yourself!")
+	}
+
+	// check seller data
+	querySellerString := fmt.Sprintf("{\"selector\": {\"user_addresses\": {\"$elemMatch\": {\"value\": \"%s\"}},\"doc_type\":\"%s\"}}", data.SellerAddress, utils.DocTypeUser)
+	sellerData, sellerID, err := utils.Get(c, querySellerString, "Seller %s does not exist!", data.SellerAddress)
+	if sellerData == nil {
+		return nil, status.ErrUserNotFound.WithError(err)
+	}
+	seller := User{}
+	err = json.Unmarshal(sellerData, &seller)
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+	if addressByValue(seller.UserAddresses, data.SellerAddress).Retired {
+		return nil, status.ErrAddressRetired.WithMessagef("Address %s has been retired!", data.SellerAddress)
+	}
+
+	if data.ArbiterID != "" {
+		if data.ArbiterID == data.BuyerID || data.ArbiterID == sellerID {
+			return nil, status.ErrStatusUnprocessableEntity.WithMessagef("The arbiter can't be a party of the escrow!")
+		}
+		_, err = getUser(c, data.ArbiterID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data.Quantity, err = data.Quantity.For(data.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	// the seller must be allowed to hold the asset, the restricted assets need a transfer approval
+	if data.Code != utils.WalletCoinSymbol {
+		policy, err := getAssetPolicy(c, data.Code)
+		if err != nil {
+			return nil, err
+		}
+		if policy.Restricted {
+			return nil, status.ErrForbidden.WithMessagef("Restricted asset %s can't be escrowed!", data.Code)
+		}
+		if policy.Allowlisted && sellerID != policy.IssuerID {
+			eligible, err := isAllowlisted(c, data.Code, data.SellerAddress)
+			if err != nil {
+				return nil, err
+			}
+			if !eligible {
+				return nil, status.ErrNotAllowlisted.WithMessagef("Receiver %s is not on the allowlist of %s!", data.SellerAddress, data.Code)
+			}
+		}
+	}
+
+	// check both parties against the blocked list
+	alert := ComplianceAlert{UserID: data.BuyerID, Counterparty: data.SellerAddress, Code: data.Code, Quantity: data.Quantity}
+	err = screenAddresses(c, alert, append([]string{data.SellerAddress}, addressValues(buyer.UserAddresses)...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkAvailable(c, data.BuyerID, buyer, from, data.Code, data.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	assetLabel, err := adjustHolding(c, data.BuyerID, &buyer, from, data.Code, data.Quantity.Neg())
+	if err != nil {
+		return nil, err
+	}
+
+	txID := c.Stub().GetTxID()
+	data.EscrowID = txID
+	data.BuyerAddress = buyer.UserAddresses[from].Value
+	data.SellerID = sellerID
+	data.SellerQuantity = amount.Units(0)
+	data.Status = utils.EscrowFunded
+	data.BuyerConfirmed = false
+	data.SellerConfirmed = false
+	data.History = []EscrowChange{}
+	data.DocType = utils.DocTypeEscrow
+	data.CreatedAt = now.Format(time.RFC3339)
+
+	// the funds go from the address of buyer to the account of the escrow
+	entry := JournalEntry{CreatedAt: data.CreatedAt}
+	entry.move(data.Code, data.Quantity,
+		JournalLeg{Account: data.BuyerAddress, UserID: data.BuyerID, TxnType: utils.EscrowLockedTxn, AssetLabel: assetLabel, AddressValue: data.SellerAddress, Memo: data.Memo},
+		JournalLeg{Account: utils.EscrowAccount + txID, TxnType: utils.EscrowLockedTxn, AssetLabel: assetLabel})
+	err = postEntry(c, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.State().Put(data.BuyerID, buyer)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the data and return the response
+	err = data.record(c, utils.EscrowFunded, data.BuyerID, "")
+	if err != nil {
+		return nil, err
+	}
+	return putEscrow(c, data)
+}
+
+// ConfirmEscrow confirm the trade, the funds are released to the seller once both parties confirmed
+func ConfirmEscrow(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(EscrowAction)
+
+	escrow, err := getPartyEscrow(c, data.UserID, data.EscrowID, utils.EscrowFunded)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.UserID == escrow.BuyerID {
+		escrow.BuyerConfirmed = true
+	} else {
+		escrow.SellerConfirmed = true
+	}
+	err = escrow.record(c, utils.EscrowConfirmed, data.UserID, data.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if escrow.BuyerConfirmed && escrow.SellerConfirmed {
+		err = settleEscrow(c, &escrow, escrow.Quantity, utils.EscrowReleasedTxn, data.UserID)
+		if err != nil {
+			return nil, err
+		}
+		escrow.Status = utils.EscrowReleased
+		err = escrow.record(c, utils.EscrowReleased, data.UserID, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Save the data and return the response
+	return putEscrow(c, escrow)
+}
+
+// DisputeEscrow open a dispute, the arbiter then decides how the funds are split before the end of
+// the dispute period
+func DisputeEscrow(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(EscrowAction)
+
+	escrow, err := getPartyEscrow(c, data.UserID, data.EscrowID, utils.EscrowFunded)
+	if err != nil {
+		return nil, err
+	}
+	if escrow.ArbiterID == "" {
+		return nil, status.ErrStatusUnprocessableEntity.WithMessagef("Escrow %s has no arbiter!", data.EscrowID)
+	}
+
+	// the buyer is refunded when the arbiter does not decide in time
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	escrow.DisputeExpiresAt = now.Add(time.Duration(utils.EscrowDisputePeriod) * time.Second).Format(time.RFC3339)
+	escrow.Status = utils.EscrowDisputed
+	err = escrow.record(c, utils.EscrowDisputed, data.UserID, data.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the data and return the response
+	return putEscrow(c, escrow)
+}
+
+// ResolveEscrow decide the dispute, the seller is paid the given quantity and the buyer the rest
+func ResolveEscrow(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(EscrowDecision)
+
+	_, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	escrow, err := getEscrow(c, data.EscrowID)
+	if err != nil {
+		return nil, err
+	}
+	if escrow.ArbiterID != data.UserID {
+		return nil, status.ErrForbidden.WithMessagef("Only the arbiter of escrow %s can decide its dispute!", data.EscrowID)
+	}
+	if escrow.Status != utils.EscrowDisputed {
+		return nil, status.ErrEscrowClosed.WithMessagef("Escrow %s is %s!", data.EscrowID, escrow.Status)
+	}
+
+	sellerQuantity, err := data.SellerQuantity.For(escrow.Code)
+	if err != nil {
+		return nil, err
+	}
+	if sellerQuantity.Cmp(escrow.Quantity) > 0 {
+		return nil, status.ErrStatusUnprocessableEntity.WithMessagef("Quantity should be less or equal to %s", escrow.Quantity)
+	}
+
+	err = settleEscrow(c, &escrow, sellerQuantity, utils.EscrowReleasedTxn, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	escrow.Status = utils.EscrowResolved
+	err = escrow.record(c, utils.EscrowResolved, data.UserID, data.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the data and return the response
+	return putEscrow(c, escrow)
+}
+
+// RefundEscrow refund the funds of the escrow which has expired to the buyer, anyone can trigger the
+// refund once the escrow or its dispute has expired
+func RefundEscrow(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(EscrowAction)
+
+	// the user who triggers the refund is kept in the history of the escrow
+	_, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	escrow, err := getEscrow(c, data.EscrowID)
+	if err != nil {
+		return nil, err
+	}
+	if escrow.Status == utils.EscrowFunded || escrow.Status == utils.EscrowDisputed {
+		return nil, status.ErrStatusConflict.WithMessagef("Escrow %s can't be refunded before %s!", data.EscrowID, escrowExpiry(escrow))
+	}
+	if escrow.Status != utils.EscrowExpired {
+		return nil, status.ErrEscrowClosed.WithMessagef("Escrow %s is %s!", data.EscrowID, escrow.Status)
+	}
+
+	err = refundEscrow(c, &escrow, data.UserID, data.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the data and return the response
+	return putEscrow(c, escrow)
+}
+
+// ListExpiredEscrows list the escrows which have expired and can be refunded by anyone, oldest first.
+// Each refund reads and writes the holdings of the buyer, so they are refunded one per transaction
+func ListExpiredEscrows(c router.Context) (interface{}, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"$or\":[{\"status\":\"%s\"},{\"status\":\"%s\"}],\"doc_type\":\"%s\"}}", utils.EscrowFunded, utils.EscrowDisputed, utils.DocTypeEscrow)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := EscrowsResponse{Escrows: []Escrow{}}
+	for _, result := range results {
+		escrow := Escrow{}
+		err = json.Unmarshal(result.Value, &escrow)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		escrow.Status = escrowStatus(escrow, now)
+		if escrow.Status == utils.EscrowExpired {
+			responseBody.Escrows = append(responseBody.Escrows, escrow)
+		}
+	}
+	sort.SliceStable(responseBody.Escrows, func(i, j int) bool {
+		return responseBody.Escrows[i].CreatedAt < responseBody.Escrows[j].CreatedAt
+	})
+
+	// return the response
+	return responseBody, nil
+}
+
+// ListEscrows list the escrows of which the user is the buyer, the seller or the arbiter, newest first
+func ListEscrows(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(UserId)
+
+	queryString := fmt.Sprintf("{\"selector\":{\"$or\":[{\"buyer_id\":\"%s\"},{\"seller_id\":\"%s\"},{\"arbiter_id\":\"%s\"}],\"doc_type\":\"%s\"}}", data.ID, data.ID, data.ID, utils.DocTypeEscrow)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := EscrowsResponse{Escrows: []Escrow{}}
+	for _, result := range results {
+		escrow := Escrow{}
+		err = json.Unmarshal(result.Value, &escrow)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		escrow.Status = escrowStatus(escrow, now)
+		responseBody.Escrows = append(responseBody.Escrows, escrow)
+	}
+	sort.SliceStable(responseBody.Escrows, func(i, j int) bool {
+		return responseBody.Escrows[i].CreatedAt > responseBody.Escrows[j].CreatedAt
+	})
+
+	// return the response
+	return responseBody, nil
+}
+
+// getEscrow returns the escrow with its current status
+func getEscrow(c router.Context, escrowID string) (Escrow, error) {
+	escrowData, err := c.State().Get([]string{utils.DocTypeEscrow, escrowID}, &Escrow{})
+	if err != nil {
+		return Escrow{}, status.ErrEscrowNotFound.WithMessagef("Escrow %s does not exist!", escrowID)
+	}
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return Escrow{}, err
+	}
+	escrow := escrowData.(Escrow)
+	escrow.Status = escrowStatus(escrow, now)
+	return escrow, nil
+}
+
+// getPartyEscrow returns the escrow of which the invoking user is the buyer or the seller, it must be
+// in the given status
+func getPartyEscrow(c router.Context, userID string, escrowID string, escrowStatus string) (Escrow, error) {
+	_, err := authorizeUser(c, userID)
+	if err != nil {
+		return Escrow{}, err
+	}
+	escrow, err := getEscrow(c, escrowID)
+	if err != nil {
+		return Escrow{}, err
+	}
+	if userID != escrow.BuyerID && userID != escrow.SellerID {
+		return Escrow{}, status.ErrForbidden.WithMessagef("You are not a party of escrow %s!", escrowID)
+	}
+	if escrow.Status != escrowStatus {
+		return Escrow{}, status.ErrEscrowClosed.WithMessagef("Escrow %s is %s!", escrowID, escrow.Status)
+	}
+	return escrow, nil
+}
+
+// refundEscrow pays the funds of the expired escrow back to the buyer
+func refundEscrow(c router.Context, escrow *Escrow, userID string, reason string) error {
+	err := settleEscrow(c, escrow, amount.Units(0), utils.EscrowRefundedTxn, userID)
+	if err != nil {
+		return err
+	}
+	escrow.Status = utils.EscrowRefunded
+	return escrow.record(c, utils.EscrowRefunded, userID, reason)
+}
+
+// settleEscrow pays the quantity to the seller and the rest to the buyer out of the account of the escrow
+func settleEscrow(c router.Context, escrow *Escrow, sellerQuantity amount.Amount, txnType string, userID string) error {
+	buyerQuantity, err := escrow.Quantity.Sub(sellerQuantity)
+	if err != nil {
+		return err
+	}
+
+	payouts := []struct {
+		userID       string
+		address      string
+		counterparty string
+		quantity     amount.Amount
+	}{
+		{escrow.SellerID, escrow.SellerAddress, escrow.BuyerAddress, sellerQuantity},
+		{escrow.BuyerID, escrow.BuyerAddress, escrow.SellerAddress, buyerQuantity},
+	}
+
+	// the payees may have been blocked while the funds were held, they are all screened before
+	// anything is paid out
+	for _, payout := range payouts {
+		if payout.quantity.IsZero() {
+			continue
+		}
+		err = screenAddresses(c, ComplianceAlert{UserID: userID, Counterparty: payout.counterparty, Code: escrow.Code, Quantity: payout.quantity}, payout.address)
+		if err != nil {
+			return err
+		}
+	}
+
+	entry := JournalEntry{}
+	for _, payout := range payouts {
+		if payout.quantity.IsZero() {
+			continue
+		}
+
+		user, err := getUser(c, payout.userID)
+		if err != nil {
+			return err
+		}
+		_, err = allocateBalance(&user)
+		if err != nil {
+			return err
+		}
+		to, ok := findAddress(user.UserAddresses, payout.address)
+		if !ok {
+			return status.ErrAddressNotOwned.WithMessagef("Address %s does not belong to you!", payout.address)
+		}
+		assetLabel, err := adjustHolding(c, payout.userID, &user, to, escrow.Code, payout.quantity)
+		if err != nil {
+			return err
+		}
+		err = c.State().Put(payout.userID, user)
+		if err != nil {
+			return err
+		}
+
+		entry.move(escrow.Code, payout.quantity,
+			JournalLeg{Account: utils.EscrowAccount + escrow.EscrowID, TxnType: txnType, AssetLabel: assetLabel},
+			JournalLeg{Account: payout.address, UserID: payout.userID, TxnType: txnType, AssetLabel: assetLabel, AddressValue: payout.counterparty, Memo: escrow.Memo})
+	}
+
+	escrow.SellerQuantity = sellerQuantity
+	return postEntry(c, entry)
+}
+
+// record adds the change of the escrow by the user to its history
+func (escrow *Escrow) record(c router.Context, action string, userID string, reason string) error {
+	updatedAt, err := utils.TxTime(c)
+	if err != nil {
+		return err
+	}
+	escrow.UpdatedAt = updatedAt.Format(time.RFC3339)
+	escrow.History = append(escrow.History, EscrowChange{Action: action, Status: escrow.Status, UserID: userID, Reason: reason, TransactionID: c.Stub().GetTxID(), CreatedAt: escrow.UpdatedAt})
+	return nil
+}
+
+// putEscrow saves the escrow and emits its status for the wallet app
+func putEscrow(c router.Context, escrow Escrow) (interface{}, error) {
+	err := c.State().Put([]string{utils.DocTypeEscrow, escrow.EscrowID}, escrow)
+	if err != nil {
+		return nil, err
+	}
+	err = c.SetEvent(utils.EscrowEvent, escrow)
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+	return escrow, nil
+}
+
+// escrowExpiry returns the time after which the escrow can be refunded, the end of the dispute for
+// the disputed escrows
+func escrowExpiry(escrow Escrow) string {
+	if escrow.Status == utils.EscrowDisputed {
+		return escrow.DisputeExpiresAt
+	}
+	return escrow.ExpiresAt
+}
+
+// escrowStatus returns the status of the escrow at now, the funded escrows past their expiry and the
+// disputed escrows past the end of their dispute are expired
+func escrowStatus(escrow Escrow, now time.Time) string {
+	if escrow.Status != utils.EscrowFunded && escrow.Status != utils.EscrowDisputed {
+		return escrow.Status
+	}
+	expiresAt, err := time.Parse(time.RFC3339, escrowExpiry(escrow))
+	if err == nil && !now.Before(expiresAt) {
+		return utils.EscrowExpired
+	}
+	return escrow.Status
+}
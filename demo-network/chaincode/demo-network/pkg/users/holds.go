@@ -0,0 +1,314 @@
+// Package users Funds hold related functions
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/amount"
+	"github.com/chaincode/demo-network/pkg/core/middleware"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// PlaceHold reserve a part of the coins or of the asset of the address for the merchant. The
+// quantity stays with the user but can't be spent until the hold is captured, released or expired
+func PlaceHold(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(Hold)
+
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, _ := time.Parse(time.RFC3339, data.ExpiresAt)
+	if !expiresAt.After(now) {
+		return nil, status.ErrStatusUnprocessableEntity.WithMessagef("Expiry %s is not in the future!", data.ExpiresAt)
+	}
+
+	// the hold is placed on the given address or else on the primary address
+	user, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = allocateBalance(&user)
+	if err != nil {
+		return nil, err
+	}
+	from, err := spendingAddress(user, data.Address)
+	if err != nil {
+		return nil, err
+	}
+	address := user.UserAddresses[from].Value
+	if _, ok := findAddress(user.UserAddresses, data.MerchantAddress); ok {
+		return nil, status.ErrSelfTransfer.WithMessagef("You can't place a hold for yourself!")
+	}
+
+	// check merchant data
+	queryMerchantString := fmt.Sprintf("{\"selector\": {\"user_addresses\": {\"$elemMatch\": {\"value\": \"%s\"}},\"doc_type\":\"%s\"}}", data.MerchantAddress, utils.DocTypeUser)
+	merchantData, merchantID, err := utils.Get(c, queryMerchantString, "Merchant %s does not exist!", data.MerchantAddress)
+	if merchantData == nil {
+		return nil, status.ErrUserNotFound.WithError(err)
+	}
+
+	data.Quantity, err = data.Quantity.For(data.Code)
+	if err != nil {
+		return nil, err
+	}
+	err = checkAvailable(c, data.UserID, user, from, data.Code, data.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	txID := c.Stub().GetTxID()
+	data.HoldID = txID
+	data.Address = address
+	data.MerchantID = merchantID
+	data.CapturedQuantity = amount.Units(0)
+	data.Status = utils.HoldActive
+	data.TransactionID = ""
+	data.DocType = utils.DocTypeHold
+	data.CreatedAt = now.Format(time.RFC3339)
+	data.UpdatedAt = data.CreatedAt
+
+	// Save the data and return the response
+	return data, c.State().Put([]string{utils.DocTypeHold, txID}, data)
+}
+
+// CaptureHold capture all or part of the hold, the quantity is transferred to the merchant with a
+// normal transfer and the rest of the hold is released
+func CaptureHold(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(HoldCapture)
+
+	hold, err := getMerchantHold(c, data.UserID, data.HoldID)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity := hold.Quantity
+	if !data.Quantity.IsZero() {
+		quantity, err = data.Quantity.For(hold.Code)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if quantity.Cmp(hold.Quantity) > 0 {
+		return nil, status.ErrStatusUnprocessableEntity.WithMessagef("Quantity should be less or equal to %s", hold.Quantity)
+	}
+
+	// the merchant has asked for the asset, so it is not held for acceptance
+	options := transferOptions{consented: true, hold: hold.HoldID}
+	var response interface{}
+	if hold.Code == utils.WalletCoinSymbol {
+		transfer := SendBalance{From: hold.UserID, FromAddress: hold.Address, To: hold.MerchantAddress, Quantity: quantity, Label: hold.Label, Memo: hold.Memo}
+		err = middleware.Validated(transfer)
+		if err != nil {
+			return nil, err
+		}
+		response, err = transferBalance(c, transfer, options)
+	} else {
+		transfer := GetTransaction{From: hold.UserID, FromAddress: hold.Address, To: hold.MerchantAddress, Code: hold.Code, Quantity: quantity, Label: hold.Label, Memo: hold.Memo}
+		err = middleware.Validated(transfer)
+		if err != nil {
+			return nil, err
+		}
+		response, err = transferAsset(c, transfer, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the transfer waits for the approval of the issuer, the hold stays active
+	if transfer, ok := response.(RestrictedTransfer); ok {
+		return transfer, nil
+	}
+
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	hold.CapturedQuantity = quantity
+	hold.TransactionID = c.Stub().GetTxID()
+	hold.Status = utils.HoldCaptured
+	hold.UpdatedAt = now.Format(time.RFC3339)
+
+	// Save the data and return the response
+	return hold, c.State().Put([]string{utils.DocTypeHold, hold.HoldID}, hold)
+}
+
+// ReleaseHold release the hold, the quantity can be spent again by the user
+func ReleaseHold(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(HoldID)
+
+	hold, err := getMerchantHold(c, data.UserID, data.HoldID)
+	if err != nil {
+		return nil, err
+	}
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	hold.Status = utils.HoldReleased
+	hold.UpdatedAt = now.Format(time.RFC3339)
+
+	// Save the data and return the response
+	return hold, c.State().Put([]string{utils.DocTypeHold, hold.HoldID}, hold)
+}
+
+// getMerchantHold returns the active hold which the invoking merchant can capture or release
+func getMerchantHold(c router.Context, merchantID string, holdID string) (Hold, error) {
+	_, err := authorizeUser(c, merchantID)
+	if err != nil {
+		return Hold{}, err
+	}
+	holdData, err := c.State().Get([]string{utils.DocTypeHold, holdID}, &Hold{})
+	if err != nil {
+		return Hold{}, status.ErrHoldNotFound.WithMessagef("Hold %s does not exist!", holdID)
+	}
+	hold := holdData.(Hold)
+	if hold.MerchantID != merchantID {
+		return Hold{}, status.ErrForbidden.WithMessagef("Hold %s is not placed for you!", holdID)
+	}
+
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return Hold{}, err
+	}
+	hold.Status = holdStatus(hold, now)
+	if hold.Status != utils.HoldActive {
+		return Hold{}, status.ErrHoldClosed.WithMessagef("Hold %s is %s!", holdID, hold.Status)
+	}
+	return hold, nil
+}
+
+// activeHolds returns the holds of user which still reserve their quantity
+func activeHolds(c router.Context, userID string) ([]Hold, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"status\":\"%s\",\"doc_type\":\"%s\"}}", userID, utils.HoldActive, utils.DocTypeHold)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+
+	holds := []Hold{}
+	for _, result := range results {
+		hold := Hold{}
+		err = json.Unmarshal(result.Value, &hold)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		if holdStatus(hold, now) == utils.HoldActive {
+			holds = append(holds, hold)
+		}
+	}
+	return holds, nil
+}
+
+// availableQuantity returns the total of the code held by the address of user minus its active
+// holds. The excepted hold is not subtracted, it is the one being captured
+func availableQuantity(c router.Context, userID string, address string, code string, total amount.Amount, except string) (amount.Amount, error) {
+	holds, err := activeHolds(c, userID)
+	if err != nil {
+		return total, err
+	}
+
+	available := total
+	for _, hold := range holds {
+		if hold.Address != address || hold.Code != code || hold.HoldID == except {
+			continue
+		}
+		available, err = available.Sub(hold.Quantity)
+		if err != nil {
+			return total, err
+		}
+	}
+	return available, nil
+}
+
+// checkAvailable checks the address of user holds the quantity of the code besides its active holds
+func checkAvailable(c router.Context, userID string, user User, address int, code string, quantity amount.Amount) error {
+	value := user.UserAddresses[address].Value
+	total := user.UserAddresses[address].Balance
+	insufficient := status.ErrInsufficientBalance
+	if code != utils.WalletCoinSymbol {
+		assetData, _, err := getAddressAsset(c, userID, value, code)
+		if assetData == nil {
+			return err
+		}
+		asset := Asset{}
+		err = json.Unmarshal(assetData, &asset)
+		if err != nil {
+			return status.ErrInternal.WithError(err)
+		}
+		total = asset.Quantity
+		insufficient = status.ErrInsufficientAsset
+	}
+
+	available, err := availableQuantity(c, userID, value, code, total, "")
+	if err != nil {
+		return err
+	}
+	if quantity.Cmp(available) > 0 {
+		return insufficient.WithMessagef("Quantity should be less or equal to %s", available)
+	}
+	return nil
+}
+
+// applyHolds sets the active holds of the sub-accounts and their balance and assets which remain available
+func applyHolds(accounts []SubAccountResponse, holds []Hold) ([]SubAccountResponse, error) {
+	for i := range accounts {
+		account := &accounts[i]
+		account.AvailableBalance = account.Balance
+		account.AvailableAssets = append([]Asset{}, account.Assets...)
+		account.Holds = []Hold{}
+
+		for _, hold := range holds {
+			if hold.Address != account.Address {
+				continue
+			}
+			account.Holds = append(account.Holds, hold)
+
+			var err error
+			if hold.Code == utils.WalletCoinSymbol {
+				account.AvailableBalance, err = account.AvailableBalance.Sub(hold.Quantity)
+			} else if j, ok := assetIndex(account.AvailableAssets, hold.Code); ok {
+				account.AvailableAssets[j].Quantity, err = account.AvailableAssets[j].Quantity.Sub(hold.Quantity)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return accounts, nil
+}
+
+// assetIndex returns the index of the asset with given code
+func assetIndex(assets []Asset, code string) (int, bool) {
+	for i := range assets {
+		if assets[i].Code == code {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// holdStatus returns the status of the hold at now, the active holds past their expiry are expired
+func holdStatus(hold Hold, now time.Time) string {
+	if hold.Status != utils.HoldActive {
+		return hold.Status
+	}
+	expiresAt, err := time.Parse(time.RFC3339, hold.ExpiresAt)
+	if err == nil && !now.Before(expiresAt) {
+		return utils.HoldExpired
+	}
+	return hold.Status
+}
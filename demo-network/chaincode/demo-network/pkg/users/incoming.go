@@ -0,0 +1,251 @@
+// Package users Pending incoming transfer related functions
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// SetTransferAcceptance set whether the incoming assets of user are pending until the user accepts them
+func SetTransferAcceptance(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(TransferAcceptance)
+
+	user, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	user.RequireAcceptance = data.RequireAcceptance
+
+	responseBody := utils.ResponseMessage{Message: "Incoming assets are now received directly."}
+	if data.RequireAcceptance {
+		responseBody.Message = "Incoming assets now require your acceptance."
+	}
+
+	// Save the data and return the response
+	return responseBody, c.State().Put(data.UserID, user)
+}
+
+// ListPendingIncoming list the incoming assets which the user can still accept or reject, newest first
+func ListPendingIncoming(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(UserId)
+
+	queryString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"status\":\"%s\",\"doc_type\":\"%s\"}}", data.ID, utils.TransferPending, utils.DocTypePendingTransfer)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := PendingTransfersResponse{PendingTransfers: []PendingTransfer{}}
+	for _, result := range results {
+		transfer := PendingTransfer{}
+		err = json.Unmarshal(result.Value, &transfer)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		if pendingStatus(transfer, now) == utils.TransferPending {
+			responseBody.PendingTransfers = append(responseBody.PendingTransfers, transfer)
+		}
+	}
+	sort.SliceStable(responseBody.PendingTransfers, func(i, j int) bool {
+		return responseBody.PendingTransfers[i].CreatedAt > responseBody.PendingTransfers[j].CreatedAt
+	})
+
+	// return the response
+	return responseBody, nil
+}
+
+// AcceptPendingTransfer accept the pending asset into the holdings of the receiver address
+func AcceptPendingTransfer(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(PendingTransferID)
+
+	_, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	transfer, err := getPendingTransfer(c, data.TransferID)
+	if err != nil {
+		return nil, err
+	}
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.UserID != data.UserID {
+		return nil, status.ErrForbidden.WithMessagef("Transfer %s is not addressed to you!", data.TransferID)
+	}
+	if pendingStatus(transfer, now) != utils.TransferPending {
+		return nil, status.ErrTransferNotPending.WithMessagef("Transfer %s has expired!", data.TransferID)
+	}
+	// the parties may have been blocked while the transfer was pending
+	err = screenAddresses(c, ComplianceAlert{UserID: data.UserID, Counterparty: transfer.SenderAddress, Code: transfer.Code, Quantity: transfer.Quantity}, transfer.Address, transfer.SenderAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := JournalEntry{}
+	entry.move(transfer.Code, transfer.Quantity,
+		JournalLeg{Account: utils.PendingAccount + transfer.Address, TxnType: utils.AssetTxnType, AssetLabel: transfer.AssetLabel},
+		JournalLeg{Account: transfer.Address, UserID: transfer.UserID, TxnType: utils.AssetTxnType, AssetLabel: transfer.AssetLabel, AddressValue: transfer.SenderAddress, LabelValue: transfer.LabelValue, AddressBookLabel: transfer.AddressBookLabel, Memo: transfer.Memo, EncryptedMemo: transfer.EncryptedMemo})
+	err = postEntry(c, entry)
+	if err != nil {
+		return nil, err
+	}
+	err = creditAsset(c, c.Stub().GetTxID(), Asset{UserID: transfer.UserID, Address: transfer.Address, Code: transfer.Code, Label: transfer.AssetLabel, Quantity: transfer.Quantity})
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the data and return the response
+	return closeTransfer(c, transfer, utils.TransferAccepted)
+}
+
+// RejectPendingTransfer reject the pending asset, it is returned to the address of the sender
+func RejectPendingTransfer(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(PendingTransferID)
+
+	_, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	transfer, err := getPendingTransfer(c, data.TransferID)
+	if err != nil {
+		return nil, err
+	}
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.UserID != data.UserID {
+		return nil, status.ErrForbidden.WithMessagef("Transfer %s is not addressed to you!", data.TransferID)
+	}
+	if pendingStatus(transfer, now) != utils.TransferPending {
+		return nil, status.ErrTransferNotPending.WithMessagef("Transfer %s has expired!", data.TransferID)
+	}
+
+	err = returnTransfer(c, transfer, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the data and return the response
+	return closeTransfer(c, transfer, utils.TransferRejected)
+}
+
+// RefundPendingTransfer refund the pending asset which the receiver has not accepted before its expiry
+func RefundPendingTransfer(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(PendingTransferID)
+
+	_, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	transfer, err := getPendingTransfer(c, data.TransferID)
+	if err != nil {
+		return nil, err
+	}
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.SenderID != data.UserID {
+		return nil, status.ErrForbidden.WithMessagef("Transfer %s was not sent by you!", data.TransferID)
+	}
+	if pendingStatus(transfer, now) != utils.TransferExpired {
+		return nil, status.ErrStatusConflict.WithMessagef("Transfer %s can't be refunded before %s!", data.TransferID, transfer.ExpiresAt)
+	}
+
+	err = returnTransfer(c, transfer, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the data and return the response
+	return closeTransfer(c, transfer, utils.TransferRefunded)
+}
+
+// holdTransfer saves the asset which waits in the pending account for the acceptance of the receiver
+func holdTransfer(c router.Context, transfer PendingTransfer) error {
+	createdAt, err := utils.TxTime(c)
+	if err != nil {
+		return err
+	}
+	transfer.Status = utils.TransferPending
+	transfer.ExpiresAt = createdAt.Add(time.Duration(utils.PendingTransferExpiry) * time.Second).Format(time.RFC3339)
+	transfer.DocType = utils.DocTypePendingTransfer
+	transfer.CreatedAt = createdAt.Format(time.RFC3339)
+	transfer.UpdatedAt = transfer.CreatedAt
+	return c.State().Put([]string{utils.DocTypePendingTransfer, transfer.TransferID}, transfer)
+}
+
+// getPendingTransfer returns the transfer which has not been accepted, rejected or refunded yet
+func getPendingTransfer(c router.Context, transferID string) (PendingTransfer, error) {
+	transferData, err := c.State().Get([]string{utils.DocTypePendingTransfer, transferID}, &PendingTransfer{})
+	if err != nil {
+		return PendingTransfer{}, status.ErrTransferNotFound.WithMessagef("Transfer %s does not exist!", transferID)
+	}
+	transfer := transferData.(PendingTransfer)
+	if transfer.Status != utils.TransferPending {
+		return PendingTransfer{}, status.ErrTransferNotPending.WithMessagef("Transfer %s is already %s!", transferID, transfer.Status)
+	}
+	return transfer, nil
+}
+
+// returnTransfer moves the pending asset back to the address of the sender, for the user who rejects
+// or refunds the transfer
+func returnTransfer(c router.Context, transfer PendingTransfer, userID string) error {
+	// the sender may have been blocked while the transfer was pending
+	err := screenAddresses(c, ComplianceAlert{UserID: userID, Counterparty: transfer.Address, Code: transfer.Code, Quantity: transfer.Quantity}, transfer.SenderAddress)
+	if err != nil {
+		return err
+	}
+
+	entry := JournalEntry{}
+	entry.move(transfer.Code, transfer.Quantity,
+		JournalLeg{Account: utils.PendingAccount + transfer.Address, TxnType: utils.AssetReturnedTxn, AssetLabel: transfer.AssetLabel},
+		JournalLeg{Account: transfer.SenderAddress, UserID: transfer.SenderID, TxnType: utils.AssetReturnedTxn, AssetLabel: transfer.AssetLabel, AddressValue: transfer.Address})
+	err = postEntry(c, entry)
+	if err != nil {
+		return err
+	}
+	return creditAsset(c, c.Stub().GetTxID(), Asset{UserID: transfer.SenderID, Address: transfer.SenderAddress, Code: transfer.Code, Label: transfer.AssetLabel, Quantity: transfer.Quantity})
+}
+
+// closeTransfer saves the transfer with its final status
+func closeTransfer(c router.Context, transfer PendingTransfer, transferStatus string) (interface{}, error) {
+	updatedAt, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	transfer.Status = transferStatus
+	transfer.UpdatedAt = updatedAt.Format(time.RFC3339)
+	return transfer, c.State().Put([]string{utils.DocTypePendingTransfer, transfer.TransferID}, transfer)
+}
+
+// pendingStatus returns the status of the transfer at now, the pending transfers past their expiry are
+// expired
+func pendingStatus(transfer PendingTransfer, now time.Time) string {
+	if transfer.Status != utils.TransferPending {
+		return transfer.Status
+	}
+	expiresAt, err := time.Parse(time.RFC3339, transfer.ExpiresAt)
+	if err == nil && !now.Before(expiresAt) {
+		return utils.TransferExpired
+	}
+	return transfer.Status
+}
@@ -0,0 +1,185 @@
+// Package users Double-entry journal related functions
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/amount"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// GetTrialBalance sums the legs of all the journal entries per account and code. The totals of
+// every code must be zero, otherwise value has been created or lost outside of the journal
+func GetTrialBalance(c router.Context) (interface{}, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"doc_type\":\"%s\"}}", utils.DocTypeJournalEntry)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := []TrialBalanceLine{}
+	totals := []TrialBalanceLine{}
+	accountLines := map[string]int{}
+	totalLines := map[string]int{}
+	for _, result := range results {
+		entry := JournalEntry{}
+		err = json.Unmarshal(result.Value, &entry)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+
+		for _, leg := range entry.Legs {
+			key := leg.Account + " " + leg.Code
+			if _, ok := accountLines[key]; !ok {
+				accountLines[key] = len(accounts)
+				accounts = append(accounts, TrialBalanceLine{Account: leg.Account, UserID: leg.UserID, Code: leg.Code})
+			}
+			if _, ok := totalLines[leg.Code]; !ok {
+				totalLines[leg.Code] = len(totals)
+				totals = append(totals, TrialBalanceLine{Code: leg.Code})
+			}
+			err = accounts[accountLines[key]].post(leg.Quantity)
+			if err != nil {
+				return nil, err
+			}
+			err = totals[totalLines[leg.Code]].post(leg.Quantity)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].Account != accounts[j].Account {
+			return accounts[i].Account < accounts[j].Account
+		}
+		return accounts[i].Code < accounts[j].Code
+	})
+	sort.Slice(totals, func(i, j int) bool { return totals[i].Code < totals[j].Code })
+
+	balanced := true
+	for _, total := range totals {
+		if !total.Balance.IsZero() {
+			balanced = false
+		}
+	}
+
+	// return the response
+	return TrialBalance{Accounts: accounts, Totals: totals, Balanced: balanced}, nil
+}
+
+// post adds the quantity of a leg to the debits or the credits and to the balance of the line
+func (line *TrialBalanceLine) post(quantity amount.Amount) (err error) {
+	if quantity.Sign() < 0 {
+		line.Debits, err = line.Debits.Sub(quantity)
+	} else {
+		line.Credits, err = line.Credits.Add(quantity)
+	}
+	if err != nil {
+		return err
+	}
+	line.Balance, err = line.Balance.Add(quantity)
+	return err
+}
+
+// move adds the debit of the from leg and the credit of the to leg to the entry, both of the
+// quantity of the code
+func (entry *JournalEntry) move(code string, quantity amount.Amount, from JournalLeg, to JournalLeg) {
+	from.Code, from.Quantity = code, quantity.Neg()
+	to.Code, to.Quantity = code, quantity
+	entry.Legs = append(entry.Legs, from, to)
+}
+
+// postEntry checks the legs of the entry sum to zero per code and saves it under the transaction ID
+func postEntry(c router.Context, entry JournalEntry) error {
+	txID := c.Stub().GetTxID()
+
+	var codes []string
+	sums := map[string]amount.Amount{}
+	for _, leg := range entry.Legs {
+		sum, ok := sums[leg.Code]
+		if !ok {
+			codes = append(codes, leg.Code)
+		}
+		sum, err := sum.Add(leg.Quantity)
+		if err != nil {
+			return err
+		}
+		sums[leg.Code] = sum
+	}
+	for _, code := range codes {
+		if !sums[code].IsZero() {
+			return status.ErrUnbalancedEntry.WithMessagef("Journal entry %s is not balanced for %s!", txID, code)
+		}
+	}
+
+	entry.EntryID = txID
+	entry.DocType = utils.DocTypeJournalEntry
+	if entry.CreatedAt == "" {
+		createdAt, err := utils.TxTime(c)
+		if err != nil {
+			return err
+		}
+		entry.CreatedAt = createdAt.Format(time.RFC3339)
+	}
+	return c.State().Put([]string{utils.DocTypeJournalEntry, txID}, entry)
+}
+
+// view returns the transaction of the leg as shown to its user
+func (leg JournalLeg) view(entry JournalEntry, index int) TransactionResponse {
+	transaction := TransactionResponse{ID: entry.EntryID + strconv.Itoa(index+1), UserID: leg.UserID, Address: leg.Account, TxnType: leg.TxnType, Type: utils.Receive, Code: leg.Code, AssetLabel: leg.AssetLabel, Quantity: leg.Quantity, AddressValue: leg.AddressValue, LabelValue: leg.LabelValue, AddressBookLabel: leg.AddressBookLabel, Memo: leg.Memo, EncryptedMemo: leg.EncryptedMemo, DocType: utils.DocTypeTransaction, CreatedAt: entry.CreatedAt}
+	if leg.Quantity.Sign() < 0 {
+		transaction.Type = utils.Send
+		transaction.Quantity = leg.Quantity.Neg()
+	}
+	return transaction
+}
+
+// userTransactions returns the transactions of user since the time, newest first. They are derived
+// from the legs of the journal entries, together with the transactions stored before the journal
+func userTransactions(c router.Context, userID string, since string) ([]TransactionResponse, error) {
+	transactions := []TransactionResponse{}
+
+	queryString := fmt.Sprintf("{\"selector\":{\"legs\":{\"$elemMatch\":{\"user_id\":\"%s\"}},\"created_at\":{\"$gte\":\"%s\"},\"doc_type\":\"%s\"}}", userID, since, utils.DocTypeJournalEntry)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		entry := JournalEntry{}
+		err = json.Unmarshal(result.Value, &entry)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		for i, leg := range entry.Legs {
+			if leg.UserID == userID {
+				transactions = append(transactions, leg.view(entry, i))
+			}
+		}
+	}
+
+	queryString = fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"created_at\":{\"$gte\":\"%s\"},\"doc_type\":\"%s\"}}", userID, since, utils.DocTypeTransaction)
+	results, err = utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		transaction := TransactionResponse{}
+		err = json.Unmarshal(result.Value, &transaction)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		transaction.ID = result.Key
+		transactions = append(transactions, transaction)
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool { return transactions[i].CreatedAt > transactions[j].CreatedAt })
+	return transactions, nil
+}
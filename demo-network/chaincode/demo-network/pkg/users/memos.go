@@ -0,0 +1,76 @@
+// Package users Payment memo related functions
+package users
+
+import (
+	"github.com/chaincode/demo-network/pkg/core/ecies"
+	"github.com/chaincode/demo-network/pkg/core/middleware"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// SetMemoKey register the ECDSA public key which the private memos of user are encrypted to
+func SetMemoKey(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(MemoKey)
+
+	_, err := ecies.ParsePublicKey([]byte(data.PublicKey))
+	if err != nil {
+		return nil, status.ErrStatusUnprocessableEntity.WithMessagef("Public key of user %s is not a valid ECDSA key!", data.UserID)
+	}
+
+	user, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	user.MemoKey = data.PublicKey
+
+	responseBody := utils.ResponseMessage{Message: "Memo key has been registered."}
+
+	// Save the data and return the response
+	return responseBody, c.State().Put(data.UserID, user)
+}
+
+// transferMemo the memo of a transfer as stored on the legs of sender and receiver
+type transferMemo struct {
+	Text     string
+	Sender   string
+	Receiver string
+}
+
+// getTransferMemo returns the memo of the transfer. The memo of the payload is stored as it is. The
+// private memo of the transient map has been encrypted by the client to the memo keys of receiver
+// and of sender, so that only both parties can read it, and is stored as the ciphertexts
+func getTransferMemo(c router.Context, text string, sender User, receiverID string, receiver User) (transferMemo, error) {
+	memo := transferMemo{Text: text}
+
+	transient, err := c.Stub().GetTransient()
+	if err != nil {
+		return memo, status.ErrInternal.WithError(err)
+	}
+	if _, ok := transient[utils.TransientMemoKey]; !ok {
+		return memo, nil
+	}
+
+	private := PrivateMemo{}
+	err = utils.GetTransient(c, utils.TransientMemoKey, &private)
+	if err != nil {
+		return memo, err
+	}
+	err = middleware.Validated(private)
+	if err != nil {
+		return memo, err
+	}
+
+	if receiver.MemoKey == "" {
+		return memo, status.ErrMemoKeyRequired.WithMessagef("Receiver %s has not registered a memo key!", receiverID)
+	}
+	memo.Receiver = private.Receiver
+
+	// the sender can only read back the memo after registering a key
+	if sender.MemoKey != "" {
+		memo.Sender = private.Sender
+	}
+	return memo, nil
+}
@@ -0,0 +1,226 @@
+// Package users Transaction monitoring related functions
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/amount"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// SetMonitoringRules update the rules used to flag suspicious transfers of the code, the thresholds
+// are given in the decimals of the code
+func SetMonitoringRules(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(MonitoringRules)
+
+	// the asset must exist
+	if data.Code != utils.WalletCoinSymbol {
+		queryString := fmt.Sprintf("{\"selector\":{\"code\":\"%s\",\"doc_type\":\"%s\"}}", data.Code, utils.DocTypeAsset)
+		assetData, _, err := utils.Get(c, queryString, "Symbol %s does not exist!", data.Code)
+		if assetData == nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	for _, threshold := range []*amount.Amount{&data.LargeTransferThreshold, &data.StructuringMaxQuantity, &data.NewCounterpartyThreshold} {
+		*threshold, err = threshold.For(data.Code)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// set the default values for the fields
+	data.DocType = utils.DocTypeMonitoringRules
+
+	// Save the data and return the response
+	return data, c.State().Put([]string{utils.DocTypeMonitoringRules, data.Code}, data)
+}
+
+// GetMonitoringRules get the rules used to flag suspicious transfers of the code
+func GetMonitoringRules(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(MonitoringRulesQuery)
+
+	return getMonitoringRules(c, data.Code)
+}
+
+// ListAlerts get the compliance alerts, optionally filtered by status
+func ListAlerts(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(AlertFilter)
+
+	queryString := fmt.Sprintf("{\"selector\":{\"doc_type\":\"%s\"},\"sort\":[{\"created_at\":\"desc\"}]}", utils.DocTypeAlert)
+	if data.Status != "" {
+		queryString = fmt.Sprintf("{\"selector\":{\"status\":\"%s\",\"doc_type\":\"%s\"},\"sort\":[{\"created_at\":\"desc\"}]}", data.Status, utils.DocTypeAlert)
+	}
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := ComplianceAlertsResponse{Alerts: []ComplianceAlert{}}
+	for _, result := range results {
+		alert := ComplianceAlert{}
+		err = json.Unmarshal(result.Value, &alert)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		responseBody.Alerts = append(responseBody.Alerts, alert)
+	}
+
+	// return the response
+	return responseBody, nil
+}
+
+// ResolveAlert mark the compliance alert as reviewed
+func ResolveAlert(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(AlertResolution)
+
+	alertData, err := c.State().Get([]string{utils.DocTypeAlert, data.AlertID}, &ComplianceAlert{})
+	if err != nil {
+		return nil, status.ErrAlertNotFound.WithMessagef("Alert %s does not exist!", data.AlertID)
+	}
+	alert := alertData.(ComplianceAlert)
+	if alert.Status == utils.AlertStatusResolved {
+		return nil, status.ErrAlertResolved.WithMessagef("Alert %s is already resolved!", data.AlertID)
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+	resolvedBy, err := client.GetID()
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+
+	resolvedAt, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+
+	alert.Status = utils.AlertStatusResolved
+	alert.Resolution = data.Resolution
+	alert.ResolvedBy = resolvedBy
+	alert.ResolvedAt = resolvedAt.Format(time.RFC3339)
+
+	// Save the data and return the response
+	return alert, c.State().Put([]string{utils.DocTypeAlert, alert.AlertID}, alert)
+}
+
+// getMonitoringRules returns the saved monitoring rules of the code or the default ones
+func getMonitoringRules(c router.Context, code string) (MonitoringRules, error) {
+	exists, err := c.State().Exists([]string{utils.DocTypeMonitoringRules, code})
+	if err != nil {
+		return MonitoringRules{}, status.ErrInternal.WithError(err)
+	}
+	if exists {
+		rulesData, err := c.State().Get([]string{utils.DocTypeMonitoringRules, code}, &MonitoringRules{})
+		if err != nil {
+			return MonitoringRules{}, status.ErrInternal.WithError(err)
+		}
+		return rulesData.(MonitoringRules), nil
+	}
+
+	rules := MonitoringRules{
+		Code:                     code,
+		LargeTransferThreshold:   amount.Units(utils.LargeTransferThreshold),
+		StructuringMaxQuantity:   amount.Units(utils.StructuringMaxQuantity),
+		StructuringCount:         utils.StructuringCount,
+		StructuringWindow:        utils.StructuringWindow,
+		NewCounterpartyThreshold: amount.Units(utils.NewCounterpartyThreshold),
+		DocType:                  utils.DocTypeMonitoringRules,
+	}
+	for _, threshold := range []*amount.Amount{&rules.LargeTransferThreshold, &rules.StructuringMaxQuantity, &rules.NewCounterpartyThreshold} {
+		*threshold, err = threshold.For(code)
+		if err != nil {
+			return MonitoringRules{}, err
+		}
+	}
+	return rules, nil
+}
+
+// evaluateRules runs the monitoring rules against the transfer described by the alert and
+// saves an alert for every rule that matches. The transfer itself is never blocked
+func evaluateRules(c router.Context, alert ComplianceAlert, txnType string) error {
+	rules, err := getMonitoringRules(c, alert.Code)
+	if err != nil {
+		return err
+	}
+
+	// transfers above the threshold
+	if alert.Quantity.Cmp(rules.LargeTransferThreshold) > 0 {
+		largeAlert := alert
+		largeAlert.AlertType = utils.AlertLargeTransfer
+		largeAlert.Reason = fmt.Sprintf("Transfer of %s %s is above the threshold of %s.", alert.Quantity, alert.Code, rules.LargeTransferThreshold)
+		_, err = raiseAlert(c, largeAlert)
+		if err != nil {
+			return err
+		}
+	}
+
+	// many small transfers in a short window
+	if alert.Quantity.Cmp(rules.StructuringMaxQuantity) <= 0 {
+		now, err := utils.TxTime(c)
+		if err != nil {
+			return err
+		}
+		since := now.Add(-time.Duration(rules.StructuringWindow) * time.Second).Format(time.RFC3339)
+		transactions, err := userTransactions(c, alert.UserID, since)
+		if err != nil {
+			return err
+		}
+
+		small := 0
+		for _, transaction := range transactions {
+			if transaction.Type == utils.Send && transaction.TxnType == txnType && transaction.Code == alert.Code && transaction.Quantity.Cmp(rules.StructuringMaxQuantity) <= 0 {
+				small++
+			}
+		}
+
+		// the current transfer is not yet in the results
+		if small+1 >= rules.StructuringCount {
+			structuringAlert := alert
+			structuringAlert.AlertType = utils.AlertStructuring
+			structuringAlert.Reason = fmt.Sprintf("%d transfers of at most %s %s within %d seconds.", small+1, rules.StructuringMaxQuantity, alert.Code, rules.StructuringWindow)
+			_, err = raiseAlert(c, structuringAlert)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// first transfers to a new counterparty
+	if alert.Quantity.Cmp(rules.NewCounterpartyThreshold) > 0 {
+		transactions, err := userTransactions(c, alert.UserID, "")
+		if err != nil {
+			return err
+		}
+
+		previous := false
+		for _, transaction := range transactions {
+			if transaction.Type == utils.Send && transaction.AddressValue == alert.Counterparty {
+				previous = true
+			}
+		}
+		if !previous {
+			counterpartyAlert := alert
+			counterpartyAlert.AlertType = utils.AlertNewCounterparty
+			counterpartyAlert.Reason = fmt.Sprintf("First transfer to %s is %s %s, above the threshold of %s.", alert.Counterparty, alert.Quantity, alert.Code, rules.NewCounterpartyThreshold)
+			_, err = raiseAlert(c, counterpartyAlert)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
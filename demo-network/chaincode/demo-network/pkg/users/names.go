@@ -0,0 +1,240 @@
+// Package users Address name registry related functions
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/rules"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// RegisterName register a unique name for an address of user, a name which has expired can be registered again
+func RegisterName(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(NameRegistration)
+
+	createdAt, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	user, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := findAddress(user.UserAddresses, data.Address)
+	if !ok {
+		return nil, status.ErrAddressNotOwned.WithMessagef("Address %s does not belong to you!", data.Address)
+	}
+	if user.UserAddresses[i].Retired {
+		return nil, status.ErrAddressRetired.WithMessagef("Address %s has been retired!", data.Address)
+	}
+
+	_, err = getAddressName(c, data.Name)
+	if err == nil {
+		return nil, status.ErrNameTaken.WithMessagef("Name %s is already registered!", data.Name)
+	}
+	err = checkUnnamed(c, data.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	name := AddressName{Name: data.Name, UserID: data.UserID, Address: data.Address, DocType: utils.DocTypeAddressName}
+	name.ExpiresAt = createdAt.Add(time.Duration(utils.NameRegistrationPeriod) * time.Second).Format(time.RFC3339)
+	name.CreatedAt = createdAt.Format(time.RFC3339)
+	name.UpdatedAt = name.CreatedAt
+
+	// Save the data and return the response
+	return name, c.State().Put([]string{utils.DocTypeAddressName, data.Name}, name)
+}
+
+// RenewName extend the registration of the name, from its expiry or from now when it has already expired
+func RenewName(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(NameID)
+
+	_, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	nameData, err := c.State().Get([]string{utils.DocTypeAddressName, data.Name}, &AddressName{})
+	if err != nil {
+		return nil, status.ErrNameNotFound.WithMessagef("Name %s is not registered!", data.Name)
+	}
+	name := nameData.(AddressName)
+	if name.UserID != data.UserID {
+		return nil, status.ErrForbidden.WithMessagef("Name %s is not registered by you!", data.Name)
+	}
+
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	renewedFrom := now
+	if nameActive(name, now) {
+		renewedFrom, _ = time.Parse(time.RFC3339, name.ExpiresAt)
+	} else {
+		// another name may point to the address since the name lapsed
+		err = checkUnnamed(c, name.Address)
+		if err != nil {
+			return nil, err
+		}
+	}
+	name.ExpiresAt = renewedFrom.Add(time.Duration(utils.NameRegistrationPeriod) * time.Second).Format(time.RFC3339)
+	name.UpdatedAt = now.Format(time.RFC3339)
+
+	// Save the data and return the response
+	return name, c.State().Put([]string{utils.DocTypeAddressName, data.Name}, name)
+}
+
+// TransferName point the name to another address, which may belong to another user who then owns the name
+func TransferName(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(NameTransfer)
+
+	_, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	name, err := getAddressName(c, data.Name)
+	if err != nil {
+		return nil, err
+	}
+	if name.UserID != data.UserID {
+		return nil, status.ErrForbidden.WithMessagef("Name %s is not registered by you!", data.Name)
+	}
+	if name.Address == data.ToAddress {
+		return nil, status.ErrSameAddress.WithMessagef("Name %s already points to %s!", data.Name, data.ToAddress)
+	}
+
+	// check new owner data
+	queryOwnerString := fmt.Sprintf("{\"selector\": {\"user_addresses\": {\"$elemMatch\": {\"value\": \"%s\"}},\"doc_type\":\"%s\"}}", data.ToAddress, utils.DocTypeUser)
+	ownerData, ownerID, err := utils.Get(c, queryOwnerString, "Receiver %s does not exist!", data.ToAddress)
+	if ownerData == nil {
+		return nil, status.ErrUserNotFound.WithError(err)
+	}
+	owner := User{}
+	err = json.Unmarshal(ownerData, &owner)
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+	if addressByValue(owner.UserAddresses, data.ToAddress).Retired {
+		return nil, status.ErrAddressRetired.WithMessagef("Address %s has been retired!", data.ToAddress)
+	}
+	err = checkUnnamed(c, data.ToAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	name.UserID = ownerID
+	name.Address = data.ToAddress
+	name.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	// Save the data and return the response
+	return name, c.State().Put([]string{utils.DocTypeAddressName, data.Name}, name)
+}
+
+// ResolveName get the address which the name points to
+func ResolveName(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(NameQuery)
+
+	// return the response
+	return getAddressName(c, data.Name)
+}
+
+// LookupName get the name which points to the address
+func LookupName(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(NameLookup)
+
+	name, ok, err := findAddressName(c, data.Address)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, status.ErrNameNotFound.WithMessagef("Address %s has no name!", data.Address)
+	}
+
+	// return the response
+	return name, nil
+}
+
+// resolveRecipient returns the address which the registered name points to, the addresses are returned as given
+func resolveRecipient(c router.Context, recipient string) (string, error) {
+	if !rules.IsAddressName(recipient) {
+		return recipient, nil
+	}
+	name, err := getAddressName(c, recipient)
+	if err != nil {
+		return "", err
+	}
+	return name.Address, nil
+}
+
+// getAddressName returns the name which is registered and has not expired
+func getAddressName(c router.Context, addressName string) (AddressName, error) {
+	nameData, err := c.State().Get([]string{utils.DocTypeAddressName, addressName}, &AddressName{})
+	if err != nil {
+		return AddressName{}, status.ErrNameNotFound.WithMessagef("Name %s is not registered!", addressName)
+	}
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return AddressName{}, err
+	}
+	name := nameData.(AddressName)
+	if !nameActive(name, now) {
+		return AddressName{}, status.ErrNameNotFound.WithMessagef("Name %s has expired!", addressName)
+	}
+	return name, nil
+}
+
+// findAddressName returns the name which points to the address and has not expired
+func findAddressName(c router.Context, address string) (AddressName, bool, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"address\":\"%s\",\"doc_type\":\"%s\"}}", address, utils.DocTypeAddressName)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return AddressName{}, false, err
+	}
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return AddressName{}, false, err
+	}
+	for _, result := range results {
+		name := AddressName{}
+		err = json.Unmarshal(result.Value, &name)
+		if err != nil {
+			return AddressName{}, false, status.ErrInternal.WithError(err)
+		}
+		if nameActive(name, now) {
+			return name, true, nil
+		}
+	}
+	return AddressName{}, false, nil
+}
+
+// checkUnnamed checks no name points to the address yet, so the reverse lookup stays unique
+func checkUnnamed(c router.Context, address string) error {
+	name, ok, err := findAddressName(c, address)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return status.ErrNameTaken.WithMessagef("Address %s already has the name %s!", address, name.Name)
+	}
+	return nil
+}
+
+// nameActive reports whether the registration of the name has not expired at now
+func nameActive(name AddressName, now time.Time) bool {
+	expiresAt, err := time.Parse(time.RFC3339, name.ExpiresAt)
+	return err == nil && now.Before(expiresAt)
+}
@@ -0,0 +1,288 @@
+// Package users Payment request related functions
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/amount"
+	"github.com/chaincode/demo-network/pkg/core/middleware"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// CreatePaymentRequest create the request of the payee to be paid by the payer address
+func CreatePaymentRequest(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(PaymentRequest)
+
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, _ := time.Parse(time.RFC3339, data.ExpiresAt)
+	if !expiresAt.After(now) {
+		return nil, status.ErrStatusUnprocessableEntity.WithMessagef("Expiry %s is not in the future!", data.ExpiresAt)
+	}
+
+	// the payee is paid on the given address or else on the primary address
+	payee, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := spendingAddress(payee, data.Address)
+	if err != nil {
+		return nil, err
+	}
+	if payee.UserAddresses[to].Retired {
+		return nil, status.ErrAddressRetired.WithMessagef("Address %s has been retired!", payee.UserAddresses[to].Value)
+	}
+	if _, ok := findAddress(payee.UserAddresses, data.PayerAddress); ok {
+		return nil, status.ErrSelfTransfer.WithMessagef("You can't request a payment from yourself!")
+	}
+
+	// check payer data
+	queryPayerString := fmt.Sprintf("{\"selector\": {\"user_addresses\": {\"$elemMatch\": {\"value\": \"%s\"}},\"doc_type\":\"%s\"}}", data.PayerAddress, utils.DocTypeUser)
+	payerData, payerID, err := utils.Get(c, queryPayerString, "Payer %s does not exist!", data.PayerAddress)
+	if payerData == nil {
+		return nil, status.ErrUserNotFound.WithError(err)
+	}
+
+	// the asset must exist
+	if data.Code != utils.WalletCoinSymbol {
+		queryString := fmt.Sprintf("{\"selector\":{\"code\":\"%s\",\"doc_type\":\"%s\"}}", data.Code, utils.DocTypeAsset)
+		assetData, _, err := utils.Get(c, queryString, "Symbol %s does not exist!", data.Code)
+		if assetData == nil {
+			return nil, err
+		}
+	}
+	data.Quantity, err = data.Quantity.For(data.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	txID := c.Stub().GetTxID()
+	data.PaymentRequestID = txID
+	data.Address = payee.UserAddresses[to].Value
+	data.PayerID = payerID
+	data.PaidQuantity = amount.Units(0)
+	data.Status = utils.PaymentPending
+	data.TransactionIDs = []string{}
+	data.DocType = utils.DocTypePaymentRequest
+	data.CreatedAt = now.Format(time.RFC3339)
+	data.UpdatedAt = data.CreatedAt
+
+	// Save the data and return the response
+	return data, c.State().Put([]string{utils.DocTypePaymentRequest, txID}, data)
+}
+
+// ListPaymentRequests list the payment requests which the user has created or has to pay, newest first
+func ListPaymentRequests(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(PaymentRequestFilter)
+
+	queryString := fmt.Sprintf("{\"selector\":{\"$or\":[{\"user_id\":\"%s\"},{\"payer_id\":\"%s\"}],\"doc_type\":\"%s\"}}", data.UserID, data.UserID, utils.DocTypePaymentRequest)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := PaymentRequestsResponse{PaymentRequests: []PaymentRequest{}}
+	for _, result := range results {
+		request := PaymentRequest{}
+		err = json.Unmarshal(result.Value, &request)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		request.Status = paymentStatus(request, now)
+		if data.Status == "" || request.Status == data.Status {
+			responseBody.PaymentRequests = append(responseBody.PaymentRequests, request)
+		}
+	}
+	sort.SliceStable(responseBody.PaymentRequests, func(i, j int) bool {
+		return responseBody.PaymentRequests[i].CreatedAt > responseBody.PaymentRequests[j].CreatedAt
+	})
+
+	// return the response
+	return responseBody, nil
+}
+
+// PayPaymentRequest pay the remaining quantity of the payment request, or a part of it, with a normal
+// transfer. The transfer is linked to the request in the same transaction, or when the restricted
+// asset needs the approval of its issuer the request waits for the review of the transfer
+func PayPaymentRequest(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(PaymentRequestPayment)
+
+	request, err := getOpenPaymentRequest(c, data.UserID, data.PaymentRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.PendingTransfer != "" {
+		return nil, status.ErrStatusConflict.WithMessagef("Payment request %s waits for the approval of the transfer %s!", request.PaymentRequestID, request.PendingTransfer)
+	}
+
+	remaining, err := request.Quantity.Sub(request.PaidQuantity)
+	if err != nil {
+		return nil, err
+	}
+	quantity := remaining
+	if !data.Quantity.IsZero() {
+		quantity, err = data.Quantity.For(request.Code)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if quantity.Cmp(remaining) > 0 {
+		return nil, status.ErrStatusUnprocessableEntity.WithMessagef("Quantity should be less or equal to %s", remaining)
+	}
+
+	var response interface{}
+	if request.Code == utils.WalletCoinSymbol {
+		transfer := SendBalance{From: data.UserID, FromAddress: data.FromAddress, To: request.Address, Quantity: quantity, Label: data.Label, Memo: request.Memo}
+		err = middleware.Validated(transfer)
+		if err != nil {
+			return nil, err
+		}
+		response, err = transferBalance(c, transfer, transferOptions{})
+	} else {
+		transfer := GetTransaction{From: data.UserID, FromAddress: data.FromAddress, To: request.Address, Code: request.Code, Quantity: quantity, Label: data.Label, Memo: request.Memo}
+		err = middleware.Validated(transfer)
+		if err != nil {
+			return nil, err
+		}
+		response, err = transferAsset(c, transfer, transferOptions{consented: true, paymentRequest: request.PaymentRequestID})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the transfer waits for the approval of the issuer, the request is paid once it is approved
+	if transfer, ok := response.(RestrictedTransfer); ok {
+		request.PendingTransfer = transfer.TransferID
+		return transfer, putPaymentRequest(c, &request)
+	}
+
+	err = recordPayment(c, &request, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the data and return the response
+	return request, putPaymentRequest(c, &request)
+}
+
+// DeclinePaymentRequest decline the payment request, the part which was already paid stays paid
+func DeclinePaymentRequest(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(PaymentRequestID)
+
+	request, err := getOpenPaymentRequest(c, data.UserID, data.PaymentRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.PendingTransfer != "" {
+		return nil, status.ErrStatusConflict.WithMessagef("Payment request %s waits for the approval of the transfer %s!", request.PaymentRequestID, request.PendingTransfer)
+	}
+	request.Status = utils.PaymentDeclined
+
+	// Save the data and return the response
+	return request, putPaymentRequest(c, &request)
+}
+
+// recordPayment adds the quantity paid by the transaction to the request, it is settled once paid in full
+func recordPayment(c router.Context, request *PaymentRequest, quantity amount.Amount) error {
+	paidQuantity, err := request.PaidQuantity.Add(quantity)
+	if err != nil {
+		return err
+	}
+	request.PaidQuantity = paidQuantity
+	request.TransactionIDs = append(request.TransactionIDs, c.Stub().GetTxID())
+	request.Status = utils.PaymentPartiallyPaid
+	if request.PaidQuantity.Cmp(request.Quantity) == 0 {
+		request.Status = utils.PaymentSettled
+	}
+	return nil
+}
+
+// closePendingPayment releases the payment request which waited for the review of the restricted
+// transfer, the approved transfer pays the request
+func closePendingPayment(c router.Context, transfer RestrictedTransfer, approved bool) error {
+	if transfer.PaymentRequestID == "" {
+		return nil
+	}
+	requestData, err := c.State().Get([]string{utils.DocTypePaymentRequest, transfer.PaymentRequestID}, &PaymentRequest{})
+	if err != nil {
+		return status.ErrPaymentRequestNotFound.WithMessagef("Payment request %s does not exist!", transfer.PaymentRequestID)
+	}
+	request := requestData.(PaymentRequest)
+	if request.PendingTransfer != transfer.TransferID {
+		return nil
+	}
+
+	request.PendingTransfer = ""
+	if approved {
+		err = recordPayment(c, &request, transfer.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+	return putPaymentRequest(c, &request)
+}
+
+// putPaymentRequest saves the payment request with the time of its update
+func putPaymentRequest(c router.Context, request *PaymentRequest) error {
+	updatedAt, err := utils.TxTime(c)
+	if err != nil {
+		return err
+	}
+	request.UpdatedAt = updatedAt.Format(time.RFC3339)
+	return c.State().Put([]string{utils.DocTypePaymentRequest, request.PaymentRequestID}, request)
+}
+
+// getOpenPaymentRequest returns the payment request which the payer can still pay or decline
+func getOpenPaymentRequest(c router.Context, payerID string, paymentRequestID string) (PaymentRequest, error) {
+	_, err := authorizeUser(c, payerID)
+	if err != nil {
+		return PaymentRequest{}, err
+	}
+	requestData, err := c.State().Get([]string{utils.DocTypePaymentRequest, paymentRequestID}, &PaymentRequest{})
+	if err != nil {
+		return PaymentRequest{}, status.ErrPaymentRequestNotFound.WithMessagef("Payment request %s does not exist!", paymentRequestID)
+	}
+	request := requestData.(PaymentRequest)
+	if request.PayerID != payerID {
+		return PaymentRequest{}, status.ErrForbidden.WithMessagef("Payment request %s is not addressed to you!", paymentRequestID)
+	}
+
+	now, err := utils.TxTime(c)
+	if err != nil {
+		return PaymentRequest{}, err
+	}
+	request.Status = paymentStatus(request, now)
+	if request.Status != utils.PaymentPending && request.Status != utils.PaymentPartiallyPaid {
+		return PaymentRequest{}, status.ErrPaymentRequestClosed.WithMessagef("Payment request %s is %s!", paymentRequestID, request.Status)
+	}
+	return request, nil
+}
+
+// paymentStatus returns the status of the payment request at now, the open requests past their expiry
+// are expired
+func paymentStatus(request PaymentRequest, now time.Time) string {
+	if request.Status != utils.PaymentPending && request.Status != utils.PaymentPartiallyPaid {
+		return request.Status
+	}
+	expiresAt, err := time.Parse(time.RFC3339, request.ExpiresAt)
+	if err == nil && !now.Before(expiresAt) {
+		return utils.PaymentExpired
+	}
+	return request.Status
+}
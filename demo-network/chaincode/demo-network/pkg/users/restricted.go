@@ -0,0 +1,267 @@
+// Package users Restricted asset related functions
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// SetAssetRestriction restrict the transfers of the asset to the ones approved by its issuer or by
+// the transfer agent which the issuer designates
+func SetAssetRestriction(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(AssetRestriction)
+
+	_, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := getAssetPolicy(c, data.Code)
+	if err != nil {
+		return nil, err
+	}
+	if policy.IssuerID != data.UserID {
+		return nil, status.ErrForbidden.WithMessagef("Only the issuer of %s can restrict its transfers!", data.Code)
+	}
+	if data.TransferAgentID != "" {
+		_, err = getUser(c, data.TransferAgentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	policy.Restricted = data.Restricted
+	policy.TransferAgentID = data.TransferAgentID
+	updatedAt, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	policy.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	// Save the data and return the response
+	return policy, c.State().Put([]string{utils.DocTypeAssetPolicy, data.Code}, policy)
+}
+
+// ListRestrictedTransfers list the restricted transfers which the user has requested or can review, newest first
+func ListRestrictedTransfers(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(UserId)
+
+	queryString := fmt.Sprintf("{\"selector\":{\"$or\":[{\"issuer_id\":\"%s\"},{\"transfer_agent_id\":\"%s\"}],\"doc_type\":\"%s\"}}", data.ID, data.ID, utils.DocTypeAssetPolicy)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+	codes := []string{}
+	for _, result := range results {
+		policy := AssetPolicy{}
+		err = json.Unmarshal(result.Value, &policy)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		codes = append(codes, policy.Code)
+	}
+	codesBytes, _ := json.Marshal(codes)
+
+	queryString = fmt.Sprintf("{\"selector\":{\"$or\":[{\"user_id\":\"%s\"},{\"code\":{\"$in\":%s}}],\"doc_type\":\"%s\"}}", data.ID, codesBytes, utils.DocTypeRestrictedTransfer)
+	results, err = utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := RestrictedTransfersResponse{RestrictedTransfers: []RestrictedTransfer{}}
+	for _, result := range results {
+		transfer := RestrictedTransfer{}
+		err = json.Unmarshal(result.Value, &transfer)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		responseBody.RestrictedTransfers = append(responseBody.RestrictedTransfers, transfer)
+	}
+	sort.SliceStable(responseBody.RestrictedTransfers, func(i, j int) bool {
+		return responseBody.RestrictedTransfers[i].CreatedAt > responseBody.RestrictedTransfers[j].CreatedAt
+	})
+
+	// return the response
+	return responseBody, nil
+}
+
+// ApproveRestrictedTransfer approve the restricted transfer, the asset moves from sender to receiver
+// like with a normal transfer
+func ApproveRestrictedTransfer(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(TransferReview)
+
+	transfer, err := getReviewableTransfer(c, data)
+	if err != nil {
+		return nil, err
+	}
+
+	memo := transferMemo{Text: transfer.Memo, Sender: transfer.SenderMemo, Receiver: transfer.ReceiverMemo}
+	move := GetTransaction{From: transfer.UserID, FromAddress: transfer.FromAddress, To: transfer.To, Code: transfer.Code, Quantity: transfer.Quantity, Label: transfer.Label, Memo: transfer.Memo}
+	// the payee of a payment request has asked for the asset
+	_, err = transferAsset(c, move, transferOptions{consented: transfer.PaymentRequestID != "", approved: true, memo: &memo})
+	if err != nil {
+		return nil, err
+	}
+
+	err = closePendingPayment(c, transfer, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the data and return the response
+	return reviewTransfer(c, transfer, data, utils.TransferApproved)
+}
+
+// RejectRestrictedTransfer reject the restricted transfer, the asset stays with the sender
+func RejectRestrictedTransfer(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(TransferReview)
+
+	transfer, err := getReviewableTransfer(c, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = closePendingPayment(c, transfer, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the data and return the response
+	return reviewTransfer(c, transfer, data, utils.TransferRejected)
+}
+
+// requestRestrictedTransfer saves the transfer of the restricted asset for the review of the issuer,
+// with the payment request which it pays if any. The private memo is encrypted now since the
+// transient map does not reach the approval
+func requestRestrictedTransfer(c router.Context, data GetTransaction, fromAddress string, sender User, receiverID string, receiver User, paymentRequestID string) (interface{}, error) {
+	memo, err := getTransferMemo(c, data.Memo, sender, receiverID, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	txID := c.Stub().GetTxID()
+	txTime, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	createdAt := txTime.Format(time.RFC3339)
+	transfer := RestrictedTransfer{TransferID: txID, UserID: data.From, FromAddress: fromAddress, To: data.To, ReceiverID: receiverID, Code: data.Code, Quantity: data.Quantity, Label: data.Label, Memo: memo.Text, SenderMemo: memo.Sender, ReceiverMemo: memo.Receiver, Status: utils.TransferPending, PaymentRequestID: paymentRequestID, DocType: utils.DocTypeRestrictedTransfer, CreatedAt: createdAt, UpdatedAt: createdAt}
+
+	// Save the data and return the response
+	return transfer, c.State().Put([]string{utils.DocTypeRestrictedTransfer, txID}, transfer)
+}
+
+// getReviewableTransfer returns the pending restricted transfer which the invoker can review
+func getReviewableTransfer(c router.Context, data TransferReview) (RestrictedTransfer, error) {
+	transferData, err := c.State().Get([]string{utils.DocTypeRestrictedTransfer, data.TransferID}, &RestrictedTransfer{})
+	if err != nil {
+		return RestrictedTransfer{}, status.ErrTransferNotFound.WithMessagef("Transfer %s does not exist!", data.TransferID)
+	}
+	transfer := transferData.(RestrictedTransfer)
+	if transfer.Status != utils.TransferPending {
+		return RestrictedTransfer{}, status.ErrTransferNotPending.WithMessagef("Transfer %s is already %s!", data.TransferID, transfer.Status)
+	}
+
+	_, err = authorizeUser(c, data.UserID)
+	if err != nil {
+		return RestrictedTransfer{}, err
+	}
+
+	policy, err := getAssetPolicy(c, transfer.Code)
+	if err != nil {
+		return RestrictedTransfer{}, err
+	}
+	if data.UserID != policy.IssuerID && data.UserID != policy.TransferAgentID {
+		return RestrictedTransfer{}, status.ErrForbidden.WithMessagef("Only the issuer or the transfer agent of %s can review its transfers!", transfer.Code)
+	}
+	return transfer, nil
+}
+
+// reviewTransfer saves the outcome of the review with its reason and reviewer for the audit trail
+func reviewTransfer(c router.Context, transfer RestrictedTransfer, data TransferReview, transferStatus string) (interface{}, error) {
+	client, err := c.Client()
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+	reviewedBy, err := client.GetID()
+	if err != nil {
+		return nil, status.ErrInternal.WithError(err)
+	}
+
+	transfer.Status = transferStatus
+	transfer.Reason = data.Reason
+	transfer.ReviewerID = data.UserID
+	transfer.ReviewedBy = reviewedBy
+	updatedAt, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	transfer.UpdatedAt = updatedAt.Format(time.RFC3339)
+	return transfer, c.State().Put([]string{utils.DocTypeRestrictedTransfer, transfer.TransferID}, transfer)
+}
+
+// getAssetPolicy returns the policy of the asset. The assets added before the policies have none,
+// their issuer is found from the journal entry or the transaction which created them
+func getAssetPolicy(c router.Context, code string) (AssetPolicy, error) {
+	exists, err := c.State().Exists([]string{utils.DocTypeAssetPolicy, code})
+	if err != nil {
+		return AssetPolicy{}, status.ErrInternal.WithError(err)
+	}
+	if exists {
+		policyData, err := c.State().Get([]string{utils.DocTypeAssetPolicy, code}, &AssetPolicy{})
+		if err != nil {
+			return AssetPolicy{}, status.ErrInternal.WithError(err)
+		}
+		return policyData.(AssetPolicy), nil
+	}
+
+	queryString := fmt.Sprintf("{\"selector\":{\"code\":\"%s\",\"doc_type\":\"%s\"}}", code, utils.DocTypeAsset)
+	assetData, _, err := utils.Get(c, queryString, "Symbol %s does not exist!", code)
+	if assetData == nil {
+		return AssetPolicy{}, err
+	}
+	asset := Asset{}
+	err = json.Unmarshal(assetData, &asset)
+	if err != nil {
+		return AssetPolicy{}, status.ErrInternal.WithError(err)
+	}
+
+	policy := AssetPolicy{Code: code, DocType: utils.DocTypeAssetPolicy}
+	queryString = fmt.Sprintf("{\"selector\":{\"legs\":{\"$elemMatch\":{\"code\":\"%s\",\"txn_type\":\"%s\",\"user_id\":{\"$gt\":\"\"}}},\"doc_type\":\"%s\"}}", code, utils.AssetCreatedTxn, utils.DocTypeJournalEntry)
+	entryData, _, _ := utils.Get(c, queryString, "")
+	if entryData != nil {
+		entry := JournalEntry{}
+		err = json.Unmarshal(entryData, &entry)
+		if err != nil {
+			return AssetPolicy{}, status.ErrInternal.WithError(err)
+		}
+		for _, leg := range entry.Legs {
+			if leg.Code == code && leg.UserID != "" {
+				policy.IssuerID = leg.UserID
+			}
+		}
+		return policy, nil
+	}
+
+	queryString = fmt.Sprintf("{\"selector\":{\"asset_label\":\"%s\",\"txn_type\":\"%s\",\"doc_type\":\"%s\"}}", asset.Label, utils.AssetCreatedTxn, utils.DocTypeTransaction)
+	transactionData, _, _ := utils.Get(c, queryString, "")
+	if transactionData != nil {
+		transaction := Transaction{}
+		err = json.Unmarshal(transactionData, &transaction)
+		if err != nil {
+			return AssetPolicy{}, status.ErrInternal.WithError(err)
+		}
+		policy.IssuerID = transaction.UserID
+	}
+	return policy, nil
+}
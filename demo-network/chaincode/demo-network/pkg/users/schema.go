@@ -1,33 +1,54 @@
 // Package users Related functions
 package users
 
+import "github.com/chaincode/demo-network/pkg/core/amount"
+
 type Address struct {
-	UserID string `json:"user_id"`
-	Label  string `json:"label"`
-	Value  string `json:"value"`
+	UserID  string        `json:"user_id"`
+	Label   string        `json:"label"`
+	Value   string        `json:"value"`
+	Retired bool          `json:"retired"`
+	Balance amount.Amount `json:"balance"`
 }
 
 // Define the user structure, with 6 properties.  Structure tags are used by encoding/json library
 type User struct {
-	Address       string    `json:"address"`
-	WalletBalance int64     `json:"wallet_balance"`
-	Symbol        string    `json:"symbol"`
-	DocType       string    `json:"doc_type"`
-	CreatedAt     string    `json:"created_at"`
-	UserAddresses []Address `json:"user_addresses"`
-	Identity      string    `json:"identity"`
-	Secret        string    `json:"secret"`
+	Address       string        `json:"address"`
+	WalletBalance amount.Amount `json:"wallet_balance"`
+	Symbol        string        `json:"symbol"`
+	DocType       string        `json:"doc_type"`
+	CreatedAt     string        `json:"created_at"`
+	UserAddresses []Address     `json:"user_addresses"`
+	SecretHash    string        `json:"secret_hash"`
+	MemoKey       string        `json:"memo_key,omitempty"`
+	// the incoming assets are pending until the user accepts them, see SetTransferAcceptance
+	RequireAcceptance bool `json:"require_acceptance,omitempty"`
+	// the identity which created the user, only it may act as the user, see authorizeUser
+	OwnerMSPID string `json:"owner_msp_id,omitempty"`
+	OwnerID    string `json:"owner_id,omitempty"`
+	// only set on the users created before the private data collection, see MigrateUserSecrets
+	Identity string `json:"identity,omitempty"`
+	Secret   string `json:"secret,omitempty"`
 }
 
-// Define the asset structure
-type Asset struct {
+// Define the UserPrivate structure, kept in the private data collection of users
+type UserPrivate struct {
 	UserID   string `json:"user_id"`
-	Label    string `json:"label"`
-	Code     string `json:"code"`
-	Quantity int64  `json:"quantity"`
+	Identity string `json:"identity"`
+	Secret   string `json:"secret"`
 	DocType  string `json:"doc_type"`
 }
 
+// Define the asset structure
+type Asset struct {
+	UserID   string        `json:"user_id"`
+	Address  string        `json:"address"`
+	Label    string        `json:"label"`
+	Code     string        `json:"code"`
+	Quantity amount.Amount `json:"quantity"`
+	DocType  string        `json:"doc_type"`
+}
+
 // Define the CheckAssetStruct structure
 type CheckAssetStruct struct {
 	Code  string `json:"code"`
@@ -36,40 +57,83 @@ type CheckAssetStruct struct {
 
 // Define the transactions structure
 type Transaction struct {
-	UserID           string `json:"user_id"`
-	TxnType          string `json:"txn_type"`
-	Type             int32  `json:"type"`
-	Code             string `json:"code"`
-	AssetLabel       string `json:"asset_label"`
-	Quantity         int64  `json:"quantity"`
-	AddressValue     string `json:"address_value"`
-	LabelValue       string `json:"label_value"`
-	AddressBookLabel string `json:"address_book_label"`
-	DocType          string `json:"doc_type"`
-	CreatedAt        string `json:"created_at"`
+	UserID           string        `json:"user_id"`
+	Address          string        `json:"address"`
+	TxnType          string        `json:"txn_type"`
+	Type             int32         `json:"type"`
+	Code             string        `json:"code"`
+	AssetLabel       string        `json:"asset_label"`
+	Quantity         amount.Amount `json:"quantity"`
+	AddressValue     string        `json:"address_value"`
+	LabelValue       string        `json:"label_value"`
+	AddressBookLabel string        `json:"address_book_label"`
+	DocType          string        `json:"doc_type"`
+	CreatedAt        string        `json:"created_at"`
+}
+
+// Define the JournalEntry structure, one business operation whose legs sum to zero per code
+type JournalEntry struct {
+	EntryID   string       `json:"entry_id"`
+	Legs      []JournalLeg `json:"legs"`
+	DocType   string       `json:"doc_type"`
+	CreatedAt string       `json:"created_at"`
+}
+
+// Define the JournalLeg structure, a debit (negative quantity) or credit (positive quantity) of an
+// account. The accounts of users are their addresses, the other accounts are the system accounts
+type JournalLeg struct {
+	Account          string        `json:"account"`
+	UserID           string        `json:"user_id,omitempty"`
+	TxnType          string        `json:"txn_type"`
+	Code             string        `json:"code"`
+	AssetLabel       string        `json:"asset_label"`
+	Quantity         amount.Amount `json:"quantity"`
+	AddressValue     string        `json:"address_value"`
+	LabelValue       string        `json:"label_value"`
+	AddressBookLabel string        `json:"address_book_label"`
+	Memo             string        `json:"memo,omitempty"`
+	EncryptedMemo    string        `json:"encrypted_memo,omitempty"`
+}
+
+// Define the TrialBalanceLine structure, the movements of an account or of all accounts in a code
+type TrialBalanceLine struct {
+	Account string        `json:"account,omitempty"`
+	UserID  string        `json:"user_id,omitempty"`
+	Code    string        `json:"code"`
+	Debits  amount.Amount `json:"debits"`
+	Credits amount.Amount `json:"credits"`
+	Balance amount.Amount `json:"balance"`
+}
+
+// Define the TrialBalance structure
+type TrialBalance struct {
+	Accounts []TrialBalanceLine `json:"accounts"`
+	Totals   []TrialBalanceLine `json:"totals"`
+	Balanced bool               `json:"balanced"`
 }
 
 // Define the user structure, with 6 properties.  Structure tags are used by encoding/json library
 type NewUserResponse struct {
-	ID            string    `json:"_id"`
-	Address       string    `json:"address"`
-	WalletBalance int64     `json:"wallet_balance"`
-	Symbol        string    `json:"symbol"`
-	CreatedAt     string    `json:"created_at"`
-	UserAddresses []Address `json:"user_addresses"`
-	Identity      string    `json:"identity"`
-	Secret        string    `json:"secret"`
+	ID            string        `json:"_id"`
+	Address       string        `json:"address"`
+	WalletBalance amount.Amount `json:"wallet_balance"`
+	Symbol        string        `json:"symbol"`
+	CreatedAt     string        `json:"created_at"`
+	UserAddresses []Address     `json:"user_addresses"`
 }
 
 // Define the user structure, with 6 properties.  Structure tags are used by encoding/json library
 type UserResponse struct {
-	ID            string    `json:"_id"`
-	Address       string    `json:"address"`
-	WalletBalance int64     `json:"wallet_balance"`
-	Symbol        string    `json:"symbol"`
-	CreatedAt     string    `json:"created_at"`
-	UserAddresses []Address `json:"user_addresses"`
-	Identity      string    `json:"identity"`
+	ID            string        `json:"_id"`
+	Address       string        `json:"address"`
+	WalletBalance amount.Amount `json:"wallet_balance"`
+	Symbol        string        `json:"symbol"`
+	CreatedAt     string        `json:"created_at"`
+	UserAddresses []Address     `json:"user_addresses"`
+	Identity      string        `json:"identity"`
+	MemoKey       string        `json:"memo_key,omitempty"`
+	// the incoming assets are pending until the user accepts them
+	RequireAcceptance bool `json:"require_acceptance,omitempty"`
 }
 
 // Define the UserId structure
@@ -84,43 +148,370 @@ type UserSecret struct {
 
 // Define the GetTransactions structure
 type GetTransaction struct {
-	From      string `json:"from_id"`
-	To        string `json:"to_id"`
-	Code      string `json:"code"`
-	Quantity  int64  `json:"quantity"`
-	Label     string `json:"label"`
-	DocType   string `json:"doc_type"`
-	CreatedAt string `json:"created_at"`
+	From        string        `json:"from_id"`
+	FromAddress string        `json:"from_address"`
+	To          string        `json:"to_id"`
+	Code        string        `json:"code"`
+	Quantity    amount.Amount `json:"quantity"`
+	Label       string        `json:"label"`
+	Memo        string        `json:"memo"`
+	DocType     string        `json:"doc_type"`
+	CreatedAt   string        `json:"created_at"`
 }
 
 type ResponseAddAsset struct {
-	ID      string `json:"_id"`
-	Balance int64  `json:"balance"`
-	Symbol  string `json:"symbol"`
+	ID      string        `json:"_id"`
+	Balance amount.Amount `json:"balance"`
+	Symbol  string        `json:"symbol"`
 }
 
 // Define the transactions structure
 type TransactionResponse struct {
-	ID               string `json:"_id"`
-	UserID           string `json:"user_id"`
-	TxnType          string `json:"txn_type"`
-	Type             int32  `json:"type"`
-	Code             string `json:"code"`
-	AssetLabel       string `json:"asset_label"`
-	Quantity         int64  `json:"quantity"`
-	AddressValue     string `json:"address_value"`
-	LabelValue       string `json:"label_value"`
-	AddressBookLabel string `json:"address_book_label"`
-	DocType          string `json:"doc_type"`
-	CreatedAt        string `json:"created_at"`
+	ID               string        `json:"_id"`
+	UserID           string        `json:"user_id"`
+	Address          string        `json:"address"`
+	TxnType          string        `json:"txn_type"`
+	Type             int32         `json:"type"`
+	Code             string        `json:"code"`
+	AssetLabel       string        `json:"asset_label"`
+	Quantity         amount.Amount `json:"quantity"`
+	AddressValue     string        `json:"address_value"`
+	LabelValue       string        `json:"label_value"`
+	AddressBookLabel string        `json:"address_book_label"`
+	Memo             string        `json:"memo,omitempty"`
+	EncryptedMemo    string        `json:"encrypted_memo,omitempty"`
+	DocType          string        `json:"doc_type"`
+	CreatedAt        string        `json:"created_at"`
 }
 
 // Define the SendBalance structure
 type SendBalance struct {
-	From     string `json:"from_id"`
-	To       string `json:"to_id"`
-	Quantity int64  `json:"quantity"`
-	Label    string `json:"label"`
+	From        string        `json:"from_id"`
+	FromAddress string        `json:"from_address"`
+	To          string        `json:"to_id"`
+	Quantity    amount.Amount `json:"quantity"`
+	Label       string        `json:"label"`
+	Memo        string        `json:"memo"`
+}
+
+// Define the MemoKey structure, the public key which the private memos of user are encrypted to
+type MemoKey struct {
+	UserID    string `json:"user_id"`
+	PublicKey string `json:"public_key"`
+}
+
+// Define the PaymentRequest structure, a request of the payee to be paid by the payer address
+type PaymentRequest struct {
+	PaymentRequestID string        `json:"payment_request_id"`
+	UserID           string        `json:"user_id"`
+	Address          string        `json:"address"`
+	PayerID          string        `json:"payer_id"`
+	PayerAddress     string        `json:"payer_address"`
+	Code             string        `json:"code"`
+	Quantity         amount.Amount `json:"quantity"`
+	PaidQuantity     amount.Amount `json:"paid_quantity"`
+	Memo             string        `json:"memo"`
+	ExpiresAt        string        `json:"expires_at"`
+	Status           string        `json:"status"`
+	TransactionIDs   []string      `json:"transaction_ids"`
+	PendingTransfer  string        `json:"pending_transfer,omitempty"`
+	DocType          string        `json:"doc_type"`
+	CreatedAt        string        `json:"created_at"`
+	UpdatedAt        string        `json:"updated_at"`
+}
+
+// Define the PaymentRequestPayment structure, the payer pays all or part of the remaining quantity
+type PaymentRequestPayment struct {
+	UserID           string        `json:"user_id"`
+	PaymentRequestID string        `json:"payment_request_id"`
+	FromAddress      string        `json:"from_address"`
+	Quantity         amount.Amount `json:"quantity"`
+	Label            string        `json:"label"`
+}
+
+// Define the PaymentRequestID structure
+type PaymentRequestID struct {
+	UserID           string `json:"user_id"`
+	PaymentRequestID string `json:"payment_request_id"`
+}
+
+// Define the PaymentRequestFilter structure
+type PaymentRequestFilter struct {
+	UserID string `json:"user_id"`
+	Status string `json:"status"`
+}
+
+// Define the PaymentRequestsResponse structure
+type PaymentRequestsResponse struct {
+	PaymentRequests []PaymentRequest `json:"payment_requests"`
+}
+
+// Define the TransferAcceptance structure, whether the incoming assets of user wait for acceptance
+type TransferAcceptance struct {
+	UserID            string `json:"user_id"`
+	RequireAcceptance bool   `json:"require_acceptance"`
+}
+
+// Define the PendingTransfer structure, an incoming asset which the receiver has not accepted yet.
+// The asset is held by the pending account of the receiver address meanwhile
+type PendingTransfer struct {
+	TransferID       string        `json:"transfer_id"`
+	UserID           string        `json:"user_id"`
+	Address          string        `json:"address"`
+	SenderID         string        `json:"sender_id"`
+	SenderAddress    string        `json:"sender_address"`
+	Code             string        `json:"code"`
+	AssetLabel       string        `json:"asset_label"`
+	Quantity         amount.Amount `json:"quantity"`
+	LabelValue       string        `json:"label_value"`
+	AddressBookLabel string        `json:"address_book_label"`
+	Memo             string        `json:"memo,omitempty"`
+	EncryptedMemo    string        `json:"encrypted_memo,omitempty"`
+	ExpiresAt        string        `json:"expires_at"`
+	Status           string        `json:"status"`
+	DocType          string        `json:"doc_type"`
+	CreatedAt        string        `json:"created_at"`
+	UpdatedAt        string        `json:"updated_at"`
+}
+
+// Define the PendingTransferID structure
+type PendingTransferID struct {
+	UserID     string `json:"user_id"`
+	TransferID string `json:"transfer_id"`
+}
+
+// Define the PendingTransfersResponse structure
+type PendingTransfersResponse struct {
+	PendingTransfers []PendingTransfer `json:"pending_transfers"`
+}
+
+// Define the AssetPolicy structure, the issuer of the asset and whether its transfers need approval
+type AssetPolicy struct {
+	Code            string `json:"code"`
+	IssuerID        string `json:"issuer_id"`
+	IssuerOrg       string `json:"issuer_org,omitempty"`
+	Restricted      bool   `json:"restricted"`
+	TransferAgentID string `json:"transfer_agent_id,omitempty"`
+	Allowlisted     bool   `json:"allowlisted"`
+	DocType         string `json:"doc_type"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+// Define the AssetRestriction structure, the issuer restricts the transfers of the asset
+type AssetRestriction struct {
+	UserID          string `json:"user_id"`
+	Code            string `json:"code"`
+	Restricted      bool   `json:"restricted"`
+	TransferAgentID string `json:"transfer_agent_id"`
+}
+
+// Define the RestrictedTransfer structure, a transfer of a restricted asset which waits for the
+// approval of the issuer or of the transfer agent
+type RestrictedTransfer struct {
+	TransferID       string        `json:"transfer_id"`
+	UserID           string        `json:"user_id"`
+	FromAddress      string        `json:"from_address"`
+	To               string        `json:"to_id"`
+	ReceiverID       string        `json:"receiver_id"`
+	Code             string        `json:"code"`
+	Quantity         amount.Amount `json:"quantity"`
+	Label            string        `json:"label"`
+	Memo             string        `json:"memo,omitempty"`
+	SenderMemo       string        `json:"sender_memo,omitempty"`
+	ReceiverMemo     string        `json:"receiver_memo,omitempty"`
+	Status           string        `json:"status"`
+	Reason           string        `json:"reason,omitempty"`
+	ReviewerID       string        `json:"reviewer_id,omitempty"`
+	ReviewedBy       string        `json:"reviewed_by,omitempty"`
+	PaymentRequestID string        `json:"payment_request_id,omitempty"`
+	DocType          string        `json:"doc_type"`
+	CreatedAt        string        `json:"created_at"`
+	UpdatedAt        string        `json:"updated_at"`
+}
+
+// Define the TransferReview structure, the issuer or the transfer agent approves or rejects a transfer
+type TransferReview struct {
+	UserID     string `json:"user_id"`
+	TransferID string `json:"transfer_id"`
+	Reason     string `json:"reason"`
+}
+
+// Define the RestrictedTransfersResponse structure
+type RestrictedTransfersResponse struct {
+	RestrictedTransfers []RestrictedTransfer `json:"restricted_transfers"`
+}
+
+// Define the AllowlistMode structure, the issuer turns the allowlist of the asset on or off
+type AllowlistMode struct {
+	UserID  string `json:"user_id"`
+	Code    string `json:"code"`
+	Enabled bool   `json:"enabled"`
+}
+
+// Define the AllowlistAddresses structure, used to add and remove addresses in bulk
+type AllowlistAddresses struct {
+	UserID    string   `json:"user_id"`
+	Code      string   `json:"code"`
+	Addresses []string `json:"addresses"`
+}
+
+// Define the AllowlistEntry structure, an address which may hold the asset
+type AllowlistEntry struct {
+	Code      string `json:"code"`
+	Address   string `json:"address"`
+	DocType   string `json:"doc_type"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Define the AllowlistQuery structure, the address is optional
+type AllowlistQuery struct {
+	Code    string `json:"code"`
+	Address string `json:"address"`
+}
+
+// Define the AllowlistResponse structure
+type AllowlistResponse struct {
+	Code      string   `json:"code"`
+	Enabled   bool     `json:"enabled"`
+	Addresses []string `json:"addresses"`
+	Address   string   `json:"address,omitempty"`
+	Eligible  *bool    `json:"eligible,omitempty"`
+}
+
+// Define the Hold structure, a part of the balance of the address which is reserved for the
+// merchant without being moved
+type Hold struct {
+	HoldID           string        `json:"hold_id"`
+	UserID           string        `json:"user_id"`
+	Address          string        `json:"address"`
+	MerchantID       string        `json:"merchant_id"`
+	MerchantAddress  string        `json:"merchant_address"`
+	Code             string        `json:"code"`
+	Quantity         amount.Amount `json:"quantity"`
+	CapturedQuantity amount.Amount `json:"captured_quantity"`
+	Label            string        `json:"label"`
+	Memo             string        `json:"memo"`
+	ExpiresAt        string        `json:"expires_at"`
+	Status           string        `json:"status"`
+	TransactionID    string        `json:"transaction_id,omitempty"`
+	DocType          string        `json:"doc_type"`
+	CreatedAt        string        `json:"created_at"`
+	UpdatedAt        string        `json:"updated_at"`
+}
+
+// Define the HoldCapture structure, the merchant captures all or part of the hold
+type HoldCapture struct {
+	UserID   string        `json:"user_id"`
+	HoldID   string        `json:"hold_id"`
+	Quantity amount.Amount `json:"quantity"`
+}
+
+// Define the HoldID structure
+type HoldID struct {
+	UserID string `json:"user_id"`
+	HoldID string `json:"hold_id"`
+}
+
+// Define the Escrow structure, the funds of the buyer are locked until both parties confirm the
+// trade, the arbiter decides a dispute or the escrow expires
+type Escrow struct {
+	EscrowID         string         `json:"escrow_id"`
+	BuyerID          string         `json:"buyer_id"`
+	BuyerAddress     string         `json:"buyer_address"`
+	SellerID         string         `json:"seller_id"`
+	SellerAddress    string         `json:"seller_address"`
+	ArbiterID        string         `json:"arbiter_id,omitempty"`
+	Code             string         `json:"code"`
+	Quantity         amount.Amount  `json:"quantity"`
+	SellerQuantity   amount.Amount  `json:"seller_quantity"`
+	Memo             string         `json:"memo"`
+	ExpiresAt        string         `json:"expires_at"`
+	DisputeExpiresAt string         `json:"dispute_expires_at,omitempty"`
+	Status           string         `json:"status"`
+	BuyerConfirmed   bool           `json:"buyer_confirmed"`
+	SellerConfirmed  bool           `json:"seller_confirmed"`
+	History          []EscrowChange `json:"history"`
+	DocType          string         `json:"doc_type"`
+	CreatedAt        string         `json:"created_at"`
+	UpdatedAt        string         `json:"updated_at"`
+}
+
+// Define the EscrowChange structure, a change of the escrow for the audit trail
+type EscrowChange struct {
+	Action        string `json:"action"`
+	Status        string `json:"status"`
+	UserID        string `json:"user_id"`
+	Reason        string `json:"reason,omitempty"`
+	TransactionID string `json:"transaction_id"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// Define the EscrowAction structure, a party confirms or disputes the escrow, anyone refunds it once expired
+type EscrowAction struct {
+	UserID   string `json:"user_id"`
+	EscrowID string `json:"escrow_id"`
+	Reason   string `json:"reason"`
+}
+
+// Define the EscrowDecision structure, the arbiter splits the funds of the disputed escrow
+type EscrowDecision struct {
+	UserID         string        `json:"user_id"`
+	EscrowID       string        `json:"escrow_id"`
+	SellerQuantity amount.Amount `json:"seller_quantity"`
+	Reason         string        `json:"reason"`
+}
+
+// Define the EscrowsResponse structure
+type EscrowsResponse struct {
+	Escrows []Escrow `json:"escrows"`
+}
+
+// Define the AddressName structure, a unique name which points to an address of its owner until it expires
+type AddressName struct {
+	Name      string `json:"name"`
+	UserID    string `json:"user_id"`
+	Address   string `json:"address"`
+	ExpiresAt string `json:"expires_at"`
+	DocType   string `json:"doc_type"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// Define the NameRegistration structure
+type NameRegistration struct {
+	UserID  string `json:"user_id"`
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// Define the NameID structure
+type NameID struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+// Define the NameTransfer structure, the name points to the address of the new owner
+type NameTransfer struct {
+	UserID    string `json:"user_id"`
+	Name      string `json:"name"`
+	ToAddress string `json:"to_address"`
+}
+
+// Define the NameQuery structure
+type NameQuery struct {
+	Name string `json:"name"`
+}
+
+// Define the NameLookup structure, the reverse lookup of the name of an address
+type NameLookup struct {
+	Address string `json:"address"`
+}
+
+// Define the PrivateMemo structure, passed through the transient map. The client encrypts the memo
+// to the memo keys of receiver and of sender, the chaincode never sees the plain text
+type PrivateMemo struct {
+	Receiver string `json:"receiver"`
+	Sender   string `json:"sender"`
 }
 
 // Define the AddressBook structure
@@ -130,3 +521,220 @@ type AddressBook struct {
 	Label   string `json:"label"`
 	DocType string `json:"doc_type"`
 }
+
+// Define the BlockedAddress structure
+type BlockedAddress struct {
+	Address   string `json:"address"`
+	Reason    string `json:"reason"`
+	DocType   string `json:"doc_type"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Define the BlockedAddresses structure, used for bulk import
+type BlockedAddresses struct {
+	Addresses []string `json:"addresses"`
+	Reason    string   `json:"reason"`
+}
+
+// Define the BlockedAddressesResponse structure
+type BlockedAddressesResponse struct {
+	BlockedAddresses []BlockedAddress `json:"blocked_addresses"`
+}
+
+// Define the ComplianceAlert structure
+type ComplianceAlert struct {
+	AlertID      string        `json:"alert_id"`
+	AlertType    string        `json:"alert_type"`
+	UserID       string        `json:"user_id"`
+	Address      string        `json:"address"`
+	Counterparty string        `json:"counterparty"`
+	Code         string        `json:"code"`
+	Quantity     amount.Amount `json:"quantity"`
+	Function     string        `json:"function"`
+	Reason       string        `json:"reason"`
+	Status       string        `json:"status"`
+	Resolution   string        `json:"resolution"`
+	ResolvedBy   string        `json:"resolved_by"`
+	ResolvedAt   string        `json:"resolved_at"`
+	DocType      string        `json:"doc_type"`
+	CreatedAt    string        `json:"created_at"`
+}
+
+// Define the ComplianceAlertResponse structure
+type ComplianceAlertResponse struct {
+	ID      string `json:"_id"`
+	Blocked bool   `json:"blocked"`
+	Message string `json:"message"`
+}
+
+// Define the BlockedAddressID structure
+type BlockedAddressID struct {
+	Address string `json:"address"`
+}
+
+// Define the MonitoringRules structure, the rules of the transfers of one code
+type MonitoringRules struct {
+	Code                     string        `json:"code"`
+	LargeTransferThreshold   amount.Amount `json:"large_transfer_threshold"`
+	StructuringMaxQuantity   amount.Amount `json:"structuring_max_quantity"`
+	StructuringCount         int           `json:"structuring_count"`
+	StructuringWindow        int64         `json:"structuring_window"`
+	NewCounterpartyThreshold amount.Amount `json:"new_counterparty_threshold"`
+	DocType                  string        `json:"doc_type"`
+}
+
+// Define the MonitoringRulesQuery structure
+type MonitoringRulesQuery struct {
+	Code string `json:"code"`
+}
+
+// Define the AlertFilter structure
+type AlertFilter struct {
+	Status string `json:"status"`
+}
+
+// Define the AlertResolution structure
+type AlertResolution struct {
+	AlertID    string `json:"alert_id"`
+	Resolution string `json:"resolution"`
+}
+
+// Define the ComplianceAlertsResponse structure
+type ComplianceAlertsResponse struct {
+	Alerts []ComplianceAlert `json:"alerts"`
+}
+
+// Define the Contact structure
+type Contact struct {
+	UserID  string `json:"user_id"`
+	Address string `json:"address"`
+	Label   string `json:"label"`
+}
+
+// Define the ContactAddress structure
+type ContactAddress struct {
+	UserID  string `json:"user_id"`
+	Address string `json:"address"`
+}
+
+// Define the ContactsImport structure
+type ContactsImport struct {
+	UserID string `json:"user_id"`
+	Format string `json:"format"`
+	Data   string `json:"data"`
+}
+
+// Define the ContactsExport structure
+type ContactsExport struct {
+	UserID string `json:"user_id"`
+	Format string `json:"format"`
+	Data   string `json:"data"`
+}
+
+// Define the ContactsResponse structure
+type ContactsResponse struct {
+	Contacts []AddressBook `json:"contacts"`
+}
+
+// Define the AddressValue structure
+type AddressValue struct {
+	UserID string `json:"user_id"`
+	Value  string `json:"value"`
+}
+
+// Define the InternalMove structure
+type InternalMove struct {
+	UserID      string        `json:"user_id"`
+	FromAddress string        `json:"from_address"`
+	ToAddress   string        `json:"to_address"`
+	Code        string        `json:"code"`
+	Quantity    amount.Amount `json:"quantity"`
+}
+
+// Define the SubAccountResponse structure
+type SubAccountResponse struct {
+	Address          string        `json:"address"`
+	Label            string        `json:"label"`
+	Retired          bool          `json:"retired"`
+	Balance          amount.Amount `json:"balance"`
+	AvailableBalance amount.Amount `json:"available_balance"`
+	Symbol           string        `json:"symbol"`
+	Assets           []Asset       `json:"assets"`
+	AvailableAssets  []Asset       `json:"available_assets"`
+	Holds            []Hold        `json:"holds"`
+}
+
+// Define the ConfidentialFunds structure, to move funds in or out of the confidential balance
+type ConfidentialFunds struct {
+	UserID     string        `json:"user_id"`
+	Address    string        `json:"address"`
+	Collection string        `json:"collection"`
+	Code       string        `json:"code"`
+	Quantity   amount.Amount `json:"quantity"`
+}
+
+// Define the ConfidentialBalance structure, kept in the collection shared by the organizations
+type ConfidentialBalance struct {
+	UserID     string        `json:"user_id"`
+	Collection string        `json:"collection"`
+	Code       string        `json:"code"`
+	Quantity   amount.Amount `json:"quantity"`
+	DocType    string        `json:"doc_type"`
+}
+
+// Define the ConfidentialTransfer structure, the details are passed through the transient map
+type ConfidentialTransfer struct {
+	Collection string `json:"collection"`
+}
+
+// Define the ConfidentialTransferDetails structure
+type ConfidentialTransferDetails struct {
+	From     string        `json:"from_id"`
+	To       string        `json:"to_id"`
+	Code     string        `json:"code"`
+	Quantity amount.Amount `json:"quantity"`
+	Salt     string        `json:"salt"`
+}
+
+// Define the ConfidentialRecord structure, the private side of a confidential transfer
+type ConfidentialRecord struct {
+	Reference  string                      `json:"reference"`
+	ReceiverID string                      `json:"receiver_id"`
+	Details    ConfidentialTransferDetails `json:"details"`
+	DocType    string                      `json:"doc_type"`
+	CreatedAt  string                      `json:"created_at"`
+}
+
+// Define the TransferCommitment structure, the public side of a confidential transfer
+type TransferCommitment struct {
+	Reference  string `json:"reference"`
+	Collection string `json:"collection"`
+	Commitment string `json:"commitment"`
+	DocType    string `json:"doc_type"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// Define the CommitmentReference structure
+type CommitmentReference struct {
+	Reference string `json:"reference"`
+}
+
+// Define the CommitmentVerification structure
+type CommitmentVerification struct {
+	TransferCommitment
+	Verified bool               `json:"verified"`
+	Record   ConfidentialRecord `json:"record"`
+}
+
+// Define the KeyEndorsement structure, the organizations which must endorse the changes of a key
+type KeyEndorsement struct {
+	Key       string   `json:"key"`
+	Orgs      []string `json:"orgs"`
+	DocType   string   `json:"doc_type"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+// Define the EndorsementKey structure
+type EndorsementKey struct {
+	Key string `json:"key"`
+}
@@ -0,0 +1,103 @@
+// Package users Private data related functions
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chaincode/demo-network/pkg/core/auth"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// MigrateUserSecrets move the secrets and identities of the existing users into the private
+// data collection. The public documents keep only the hash of the secret from then on, the
+// values written before stay in the block history
+func MigrateUserSecrets(c router.Context) (interface{}, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"secret\":{\"$exists\":true},\"doc_type\":\"%s\"}}", utils.DocTypeUser)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		user := User{}
+		err = json.Unmarshal(result.Value, &user)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+
+		private := UserPrivate{UserID: result.Key, Identity: user.Identity, Secret: user.Secret, DocType: utils.DocTypeUserPrivate}
+		err = utils.PutPrivate(c, utils.CollectionUserPrivate, result.Key, private)
+		if err != nil {
+			return nil, err
+		}
+
+		user.SecretHash = utils.Hash(user.Secret)
+		user.Identity = ""
+		user.Secret = ""
+		err = c.State().Put(result.Key, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	responseBody := utils.ResponseMessage{Message: fmt.Sprintf("%d users have been migrated.", len(results))}
+
+	// return the response
+	return responseBody, nil
+}
+
+// MigrateUserOwners bind the existing users to the identity which created them. The identity kept
+// with the secret is the enrollment ID of the user, the users were enrolled by the organization
+// of the operator
+func MigrateUserOwners(c router.Context) (interface{}, error) {
+	claims, err := auth.FromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	queryString := fmt.Sprintf("{\"selector\":{\"owner_id\":{\"$exists\":false},\"doc_type\":\"%s\"}}", utils.DocTypeUser)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	migrated := 0
+	for _, result := range results {
+		user := User{}
+		err = json.Unmarshal(result.Value, &user)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+
+		// the users not migrated to the private data collection still hold their identity
+		identity := user.Identity
+		if identity == "" {
+			private := UserPrivate{}
+			err = utils.GetPrivate(c, utils.CollectionUserPrivate, result.Key, &private)
+			if err != nil {
+				continue
+			}
+			identity = private.Identity
+		}
+		if identity == "" {
+			continue
+		}
+
+		user.OwnerMSPID = claims.MSPID
+		user.OwnerID = identity
+		err = c.State().Put(result.Key, user)
+		if err != nil {
+			return nil, err
+		}
+		migrated++
+	}
+
+	responseBody := utils.ResponseMessage{Message: fmt.Sprintf("%d users have been bound to their identity.", migrated)}
+
+	// return the response
+	return responseBody, nil
+}
@@ -0,0 +1,190 @@
+package users
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+)
+
+const (
+	testMSPID   = "Org1MSP"
+	testAddress = "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"
+)
+
+var testSecret = strings.Repeat("s", utils.SecretLength)
+
+// createTestUser creates the user of the identity through createUser and returns its ID
+func createTestUser(t *testing.T, stub *testStub, txID string, identity string, secret string, address string) string {
+	stub.setCreator(t, testMSPID, identity)
+	stub.setTransient(t, utils.TransientUserKey, UserPrivate{Identity: identity, Secret: secret})
+	_, err := invoke(stub, txID, CreateUser, User{Address: address})
+	if err != nil {
+		t.Fatalf("createUser: %v", err)
+	}
+	return txID
+}
+
+// getTestUser logs in through getUser with the login secret
+func getTestUser(t *testing.T, stub *testStub, secret string) (UserResponse, error) {
+	stub.setTransient(t, utils.TransientUserKey, UserSecret{Secret: secret})
+	response, err := invoke(stub, "login", GetUser, nil)
+	if err != nil {
+		return UserResponse{}, err
+	}
+	user := UserResponse{}
+	return user, json.Unmarshal(response.([]byte), &user)
+}
+
+// errorCode returns the error code of the service status
+func errorCode(err error) string {
+	if serviceStatus, ok := err.(status.ErrServiceStatus); ok {
+		return serviceStatus.ErrorCode
+	}
+	return ""
+}
+
+func TestCreateUserKeepsTheSecretPrivate(t *testing.T) {
+	stub := newTestStub(t, testMSPID, "alice")
+	userID := createTestUser(t, stub, "tx1", "alice", testSecret, testAddress)
+	secret := testSecret
+
+	user, err := getUser(newContext(stub), userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Secret != "" || user.Identity != "" {
+		t.Errorf("the public user holds the secret %q and the identity %q", user.Secret, user.Identity)
+	}
+	if user.SecretHash != utils.Hash(secret) {
+		t.Errorf("secret hash is %q, want %q", user.SecretHash, utils.Hash(secret))
+	}
+	if user.OwnerMSPID != testMSPID || user.OwnerID != "alice" {
+		t.Errorf("user is bound to %s/%s, want %s/alice", user.OwnerMSPID, user.OwnerID, testMSPID)
+	}
+	if strings.Contains(string(stub.State[userID]), secret) {
+		t.Error("the public state holds the secret")
+	}
+
+	private := UserPrivate{}
+	err = json.Unmarshal(stub.PvtState[utils.CollectionUserPrivate][userID], &private)
+	if err != nil {
+		t.Fatalf("private user: %v", err)
+	}
+	if private.Secret != secret || private.Identity != "alice" || private.UserID != userID {
+		t.Errorf("private user is %+v", private)
+	}
+}
+
+func TestCreateUserRequiresTheTransientSecret(t *testing.T) {
+	tests := []struct {
+		name      string
+		transient interface{}
+		code      string
+	}{
+		{"missing", nil, status.ErrTransientRequired.ErrorCode},
+		{"short secret", UserPrivate{Identity: "alice", Secret: "short"}, status.ErrStatusUnprocessableEntity.ErrorCode},
+		{"missing identity", UserPrivate{Secret: testSecret}, status.ErrStatusUnprocessableEntity.ErrorCode},
+	}
+	for _, test := range tests {
+		stub := newTestStub(t, testMSPID, "alice")
+		if test.transient != nil {
+			stub.setTransient(t, utils.TransientUserKey, test.transient)
+		}
+		_, err := invoke(stub, "tx1", CreateUser, User{Address: testAddress})
+		if errorCode(err) != test.code {
+			t.Errorf("%s: got %v, want %s", test.name, err, test.code)
+		}
+		if len(stub.State) != 0 {
+			t.Errorf("%s: createUser wrote the state", test.name)
+		}
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	stub := newTestStub(t, testMSPID, "alice")
+	userID := createTestUser(t, stub, "tx1", "alice", testSecret, testAddress)
+	secret := testSecret
+
+	user, err := getTestUser(t, stub, secret+"alice")
+	if err != nil {
+		t.Fatalf("getUser: %v", err)
+	}
+	if user.ID != userID || user.Identity != "alice" || user.Address != testAddress {
+		t.Errorf("getUser returned %+v", user)
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+	}{
+		{"wrong identity", secret + "mallory"},
+		{"wrong secret", strings.Repeat("x", utils.SecretLength) + "alice"},
+		{"identity of another user", secret + "bob"},
+	}
+	for _, test := range tests {
+		_, err := getTestUser(t, stub, test.secret)
+		if errorCode(err) != status.ErrInvalidSecret.ErrorCode {
+			t.Errorf("%s: got %v, want %s", test.name, err, status.ErrInvalidSecret.ErrorCode)
+		}
+	}
+}
+
+func TestMigrateUserSecrets(t *testing.T) {
+	stub := newTestStub(t, testMSPID, "operator")
+	secrets := map[string]string{"legacy1": testSecret, "legacy2": strings.Repeat("t", utils.SecretLength)}
+	stub.MockTransactionStart("setup")
+	for userID, secret := range secrets {
+		legacy := User{Address: testAddress, Identity: userID + "-identity", Secret: secret, DocType: utils.DocTypeUser}
+		legacyAsBytes, _ := json.Marshal(legacy)
+		err := stub.PutState(userID, legacyAsBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	stub.MockTransactionEnd("setup")
+
+	response, err := invoke(stub, "migrate", MigrateUserSecrets, nil)
+	if err != nil {
+		t.Fatalf("migrateUserSecrets: %v", err)
+	}
+	if message := response.(utils.ResponseMessage).Message; message != "2 users have been migrated." {
+		t.Errorf("message is %q", message)
+	}
+
+	for userID, secret := range secrets {
+		user := User{}
+		err = json.Unmarshal(stub.State[userID], &user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.Secret != "" || user.Identity != "" || user.SecretHash != utils.Hash(secret) {
+			t.Errorf("%s: public user is %+v", userID, user)
+		}
+
+		private := UserPrivate{}
+		err = json.Unmarshal(stub.PvtState[utils.CollectionUserPrivate][userID], &private)
+		if err != nil {
+			t.Fatalf("%s: private user: %v", userID, err)
+		}
+		if private.Secret != secret || private.Identity != userID+"-identity" {
+			t.Errorf("%s: private user is %+v", userID, private)
+		}
+
+		_, err = getTestUser(t, stub, secret+userID+"-identity")
+		if err != nil {
+			t.Errorf("%s: getUser after the migration: %v", userID, err)
+		}
+	}
+
+	// the migrated users are not migrated again
+	response, err = invoke(stub, "migrate-again", MigrateUserSecrets, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message := response.(utils.ResponseMessage).Message; message != "0 users have been migrated." {
+		t.Errorf("message is %q", message)
+	}
+}
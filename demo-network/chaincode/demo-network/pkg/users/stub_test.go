@@ -0,0 +1,180 @@
+package users
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/s7techlab/cckit/router"
+)
+
+// testStub the mock stub of the shim with the creator, the transient map and the rich queries
+// which it does not implement
+type testStub struct {
+	*shim.MockStub
+	creator   []byte
+	transient map[string][]byte
+}
+
+// newTestStub returns a stub whose transactions are created by the enrollment of the organization
+func newTestStub(t *testing.T, mspID string, enrollmentID string) *testStub {
+	stub := &testStub{MockStub: shim.NewMockStub("demo-network", nil), transient: map[string][]byte{}}
+	stub.setCreator(t, mspID, enrollmentID)
+	return stub
+}
+
+// setCreator signs the next transactions with a new certificate of the enrollment
+func (stub *testStub) setCreator(t *testing.T, mspID string, enrollmentID string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: enrollmentID},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity := &msp.SerializedIdentity{Mspid: mspID, IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+	stub.creator, err = proto.Marshal(identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// setTransient puts the value as JSON in the transient map of the next transactions
+func (stub *testStub) setTransient(t *testing.T, key string, value interface{}) {
+	valueAsBytes, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stub.transient = map[string][]byte{key: valueAsBytes}
+}
+
+// GetCreator returns the serialized identity of the creator
+func (stub *testStub) GetCreator() ([]byte, error) {
+	return stub.creator, nil
+}
+
+// GetTransient returns the transient map
+func (stub *testStub) GetTransient() (map[string][]byte, error) {
+	return stub.transient, nil
+}
+
+// GetQueryResult matches the selector of the query against the JSON documents of the state. The
+// fields are compared for equality, $exists and $elemMatch are the only operators
+func (stub *testStub) GetQueryResult(query string) (shim.StateQueryIteratorInterface, error) {
+	parsed := struct {
+		Selector map[string]interface{} `json:"selector"`
+	}{}
+	err := json.Unmarshal([]byte(query), &parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(stub.State))
+	for key := range stub.State {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	iterator := &testIterator{}
+	for _, key := range keys {
+		doc := map[string]interface{}{}
+		if json.Unmarshal(stub.State[key], &doc) != nil {
+			continue
+		}
+		if matches(doc, parsed.Selector) {
+			iterator.results = append(iterator.results, &queryresult.KV{Namespace: stub.Name, Key: key, Value: stub.State[key]})
+		}
+	}
+	return iterator, nil
+}
+
+// matches reports whether the document matches every field of the selector
+func matches(doc map[string]interface{}, selector map[string]interface{}) bool {
+	for field, condition := range selector {
+		value, found := doc[field]
+		operator, ok := condition.(map[string]interface{})
+		switch {
+		case ok && operator["$exists"] != nil:
+			if found != operator["$exists"].(bool) {
+				return false
+			}
+		case ok && operator["$elemMatch"] != nil:
+			elements, _ := value.([]interface{})
+			matched := false
+			for _, element := range elements {
+				elementDoc, isDoc := element.(map[string]interface{})
+				if isDoc && matches(elementDoc, operator["$elemMatch"].(map[string]interface{})) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		default:
+			if !found || !reflect.DeepEqual(value, condition) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// testIterator iterates over the results of a query
+type testIterator struct {
+	results []*queryresult.KV
+}
+
+// HasNext reports whether a result remains
+func (iterator *testIterator) HasNext() bool {
+	return len(iterator.results) > 0
+}
+
+// Next returns the next result
+func (iterator *testIterator) Next() (*queryresult.KV, error) {
+	result := iterator.results[0]
+	iterator.results = iterator.results[1:]
+	return result, nil
+}
+
+// Close releases the iterator
+func (iterator *testIterator) Close() error {
+	return nil
+}
+
+// newContext returns the router context of the stub
+func newContext(stub *testStub) router.Context {
+	return router.New("test").Context(stub)
+}
+
+// invoke runs the handler in a new transaction of the stub, with the payload as the data parameter
+func invoke(stub *testStub, txID string, handler router.HandlerFunc, data interface{}) (interface{}, error) {
+	stub.MockTransactionStart(txID)
+	defer stub.MockTransactionEnd(txID)
+
+	c := newContext(stub)
+	if data != nil {
+		c.SetParam(`data`, data)
+	}
+	return handler(c)
+}
@@ -0,0 +1,301 @@
+// Package users Per-address sub-account related functions
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/amount"
+	"github.com/chaincode/demo-network/pkg/core/status"
+	"github.com/chaincode/demo-network/pkg/core/utils"
+
+	"github.com/s7techlab/cckit/router"
+)
+
+// MoveBalance move coins or assets between the addresses of user without any fee
+func MoveBalance(c router.Context) (interface{}, error) {
+	// get the data from the request and parse it as structure
+	data := c.Param(`data`).(InternalMove)
+
+	if data.FromAddress == data.ToAddress {
+		return nil, status.ErrSameAddress.WithMessagef("Please select two different addresses!")
+	}
+
+	user, err := authorizeUser(c, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = allocateBalance(&user)
+	if err != nil {
+		return nil, err
+	}
+
+	from, ok := findAddress(user.UserAddresses, data.FromAddress)
+	if !ok {
+		return nil, status.ErrAddressNotOwned.WithMessagef("Address %s does not belong to you!", data.FromAddress)
+	}
+	to, ok := findAddress(user.UserAddresses, data.ToAddress)
+	if !ok {
+		return nil, status.ErrAddressNotOwned.WithMessagef("Address %s does not belong to you!", data.ToAddress)
+	}
+	if user.UserAddresses[to].Retired {
+		return nil, status.ErrAddressRetired.WithMessagef("Address %s has been retired!", data.ToAddress)
+	}
+
+	stub := c.Stub()
+	txID := stub.GetTxID()
+	assetLabel := ""
+	data.Quantity, err = data.Quantity.For(data.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	// an address of user may have been blocked since it was added
+	err = screenAddresses(c, ComplianceAlert{UserID: data.UserID, Counterparty: data.ToAddress, Code: data.Code, Quantity: data.Quantity}, data.FromAddress, data.ToAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// the coins and the assets reserved by the holds of the address can't be moved
+	err = checkAvailable(c, data.UserID, user, from, data.Code, data.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Code == utils.WalletCoinSymbol {
+		// the wallet balance stays the same, only the addresses change
+		fromBalance, err := user.UserAddresses[from].Balance.Sub(data.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		toBalance, err := user.UserAddresses[to].Balance.Add(data.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		user.UserAddresses[from].Balance, user.UserAddresses[to].Balance = fromBalance, toBalance
+	} else {
+		fromAssetData, fromAssetKey, err := getAddressAsset(c, data.UserID, data.FromAddress, data.Code)
+		if fromAssetData == nil {
+			return nil, err
+		}
+		fromAsset := Asset{}
+		err = json.Unmarshal(fromAssetData, &fromAsset)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		assetLabel = fromAsset.Label
+
+		fromAsset.Quantity, err = fromAsset.Quantity.Sub(data.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		err = c.State().Put(fromAssetKey, fromAsset)
+		if err != nil {
+			return nil, err
+		}
+
+		toAssetData, toAssetKey, _ := getAddressAsset(c, data.UserID, data.ToAddress, data.Code)
+		toAsset := Asset{UserID: data.UserID, Address: data.ToAddress, Code: data.Code, Label: fromAsset.Label, DocType: utils.DocTypeAsset}
+		if toAssetData == nil {
+			toAssetKey = txID + strconv.Itoa(3)
+		} else {
+			err = json.Unmarshal(toAssetData, &toAsset)
+			if err != nil {
+				return nil, status.ErrInternal.WithError(err)
+			}
+		}
+		toAsset.Quantity, err = toAsset.Quantity.Add(data.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		err = c.State().Put(toAssetKey, toAsset)
+		if err != nil {
+			return nil, err
+		}
+		if toAssetData == nil {
+			err = endorseHolding(c, toAssetKey, data.Code)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	txTime, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	createdAt := txTime.Format(time.RFC3339)
+	fromLabel := user.UserAddresses[from].Label
+	toLabel := user.UserAddresses[to].Label
+
+	// the move between both addresses
+	entry := JournalEntry{CreatedAt: createdAt}
+	entry.move(data.Code, data.Quantity,
+		JournalLeg{Account: data.FromAddress, UserID: data.UserID, TxnType: utils.InternalMoveTxn, AssetLabel: assetLabel, AddressValue: data.ToAddress, LabelValue: toLabel, AddressBookLabel: toLabel},
+		JournalLeg{Account: data.ToAddress, UserID: data.UserID, TxnType: utils.InternalMoveTxn, AssetLabel: assetLabel, AddressValue: data.FromAddress, LabelValue: fromLabel, AddressBookLabel: fromLabel})
+	err = postEntry(c, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody := ResponseAddAsset{ID: txID, Balance: user.WalletBalance, Symbol: user.Symbol}
+
+	// Save the data and return the response
+	return responseBody, c.State().Put(data.UserID, user)
+}
+
+// MigrateSubAccounts assign the existing balances and assets to the primary address of every user
+func MigrateSubAccounts(c router.Context) (interface{}, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"doc_type\":\"%s\"}}", utils.DocTypeUser)
+	results, err := utils.GetAll(c, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	primaries := map[string]string{}
+	for _, result := range results {
+		user := User{}
+		err = json.Unmarshal(result.Value, &user)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		primaries[result.Key] = user.Address
+
+		allocated, err := allocateBalance(&user)
+		if err != nil {
+			return nil, err
+		}
+		if allocated {
+			err = c.State().Put(result.Key, user)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	assetsQueryString := fmt.Sprintf("{\"selector\":{\"address\":{\"$exists\":false},\"doc_type\":\"%s\"}}", utils.DocTypeAsset)
+	assetResults, err := utils.GetAll(c, assetsQueryString)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range assetResults {
+		asset := Asset{}
+		err = json.Unmarshal(result.Value, &asset)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		asset.Address = primaries[asset.UserID]
+		err = c.State().Put(result.Key, asset)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	responseBody := utils.ResponseMessage{Message: fmt.Sprintf("%d users and %d assets have been migrated.", len(results), len(assetResults))}
+
+	// return the response
+	return responseBody, nil
+}
+
+// allocateBalance assigns the part of wallet balance which is not held by any address to the
+// primary address. Users created before sub-accounts hold their whole balance this way
+func allocateBalance(user *User) (bool, error) {
+	allocated := amount.Amount{}
+	for i := range user.UserAddresses {
+		sum, err := allocated.Add(user.UserAddresses[i].Balance)
+		if err != nil {
+			return false, err
+		}
+		allocated = sum
+	}
+	if allocated.Cmp(user.WalletBalance) == 0 || len(user.UserAddresses) == 0 {
+		return false, nil
+	}
+
+	primary, ok := findAddress(user.UserAddresses, user.Address)
+	if !ok {
+		primary = 0
+	}
+	unallocated, err := user.WalletBalance.Sub(allocated)
+	if err != nil {
+		return false, err
+	}
+	balance, err := user.UserAddresses[primary].Balance.Add(unallocated)
+	if err != nil {
+		return false, err
+	}
+	user.UserAddresses[primary].Balance = balance
+	return true, nil
+}
+
+// credit adds the quantity to the address of user and to the wallet balance
+func credit(user *User, address int, quantity amount.Amount) error {
+	balance, err := user.UserAddresses[address].Balance.Add(quantity)
+	if err != nil {
+		return err
+	}
+	walletBalance, err := user.WalletBalance.Add(quantity)
+	if err != nil {
+		return err
+	}
+	user.UserAddresses[address].Balance, user.WalletBalance = balance, walletBalance
+	return nil
+}
+
+// debit takes the quantity from the address of user and from the wallet balance
+func debit(user *User, address int, quantity amount.Amount) error {
+	return credit(user, address, quantity.Neg())
+}
+
+// spendingAddress returns the index of the address to spend from, the primary one by default
+func spendingAddress(user User, address string) (int, error) {
+	if address == "" {
+		address = user.Address
+	}
+	i, ok := findAddress(user.UserAddresses, address)
+	if !ok {
+		return -1, status.ErrAddressNotOwned.WithMessagef("Address %s does not belong to you!", address)
+	}
+	return i, nil
+}
+
+// getAddressAsset finds the asset of code held by the address of user
+func getAddressAsset(c router.Context, userID string, address string, code string) ([]byte, string, error) {
+	queryString := fmt.Sprintf("{\"selector\":{\"code\":\"%s\",\"user_id\":\"%s\",\"address\":\"%s\",\"doc_type\":\"%s\"}}", code, userID, address, utils.DocTypeAsset)
+	return utils.Get(c, queryString, "Symbol %s does not exist!", code)
+}
+
+// subAccounts returns the per-address view of the balances and assets of user
+func subAccounts(user User, assets []Asset) []SubAccountResponse {
+	accounts := []SubAccountResponse{}
+	for _, address := range user.UserAddresses {
+		account := SubAccountResponse{Address: address.Value, Label: address.Label, Retired: address.Retired, Balance: address.Balance, Symbol: user.Symbol, Assets: []Asset{}}
+		for _, asset := range assets {
+			if asset.Address == address.Value {
+				account.Assets = append(account.Assets, asset)
+			}
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts
+}
+
+// aggregateAssets returns the assets of user summed up by code
+func aggregateAssets(assets []Asset) []Asset {
+	aggregated := []Asset{}
+	positions := map[string]int{}
+	for _, asset := range assets {
+		if i, ok := positions[asset.Code]; ok {
+			// the sum can't overflow, each address holds a part of the issued quantity
+			aggregated[i].Quantity, _ = aggregated[i].Quantity.Add(asset.Quantity)
+			continue
+		}
+		positions[asset.Code] = len(aggregated)
+		asset.Address = ""
+		aggregated = append(aggregated, asset)
+	}
+	return aggregated
+}
@@ -8,10 +8,12 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/chaincode/demo-network/pkg/core/amount"
+	"github.com/chaincode/demo-network/pkg/core/auth"
+	"github.com/chaincode/demo-network/pkg/core/middleware"
 	"github.com/chaincode/demo-network/pkg/core/status"
 	"github.com/chaincode/demo-network/pkg/core/utils"
 
-	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/s7techlab/cckit/router"
 )
 
@@ -20,24 +22,33 @@ func CreateUser(c router.Context) (interface{}, error) {
 	// get the data from the request and parse it as structure
 	data := c.Param(`data`).(User)
 
+	createdAt, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+
 	// set the default values for the fields
 	data.DocType = utils.DocTypeUser
-	data.WalletBalance = 10000
+	data.WalletBalance, _ = amount.Units(utils.InitialBalance).For(utils.WalletCoinSymbol)
 	data.Symbol = utils.WalletCoinSymbol
-	data.CreatedAt = time.Now().Format(time.RFC3339)
+	data.CreatedAt = createdAt.Format(time.RFC3339)
+	// the memo key is checked and registered by SetMemoKey
+	data.MemoKey = ""
 
-	// Validate the inputed data
-	err := data.Validate()
+	// the secret and identity are passed through the transient map so that they never reach the ledger
+	private := UserPrivate{}
+	err = utils.GetTransient(c, utils.TransientUserKey, &private)
 	if err != nil {
-		if _, ok := err.(validation.InternalError); ok {
-			return nil, err
-		}
-		return nil, status.ErrStatusUnprocessableEntity.WithValidationError(err.(validation.Errors))
+		return nil, err
+	}
+	err = middleware.Validated(private)
+	if err != nil {
+		return nil, err
 	}
 
 	// check if address already exists or not
 	queryString := fmt.Sprintf("{\"selector\": {\"user_addresses\": {\"$elemMatch\": {\"value\": \"%s\"}},\"doc_type\":\"%s\"}}", data.Address, utils.DocTypeUser)
-	address, userID, err := utils.Get(c, queryString, fmt.Sprintf("Address already exists with the given address %s!", data.Address))
+	address, userID, err := utils.Get(c, queryString, "Address already exists with the given address %s!", data.Address)
 
 	//If address not found
 	if address == nil {
@@ -45,12 +56,51 @@ func CreateUser(c router.Context) (interface{}, error) {
 		stub := c.Stub()
 
 		var addresses []Address
-		address1 := Address{UserID: stub.GetTxID(), Label: "Original", Value: data.Address}
+		address1 := Address{UserID: stub.GetTxID(), Label: "Original", Value: data.Address, Balance: data.WalletBalance}
 		addresses = append(addresses, address1)
 		data.UserAddresses = addresses
 
+		// keep the secret and identity in the private data collection, only the hash is public
+		private.UserID = stub.GetTxID()
+		private.DocType = utils.DocTypeUserPrivate
+		err = utils.PutPrivate(c, utils.CollectionUserPrivate, stub.GetTxID(), private)
+		if err != nil {
+			return nil, err
+		}
+		data.SecretHash = utils.Hash(private.Secret)
+		data.Identity = ""
+		data.Secret = ""
+
+		// bind the user to the identity which creates it
+		claims, err := auth.FromContext(c)
+		if err != nil {
+			return nil, err
+		}
+		data.OwnerMSPID = claims.MSPID
+		data.OwnerID = claims.EnrollmentID
+
+		// the organization of the user must endorse the changes of the user
+		org, err := creatorOrg(c)
+		if err != nil {
+			return nil, err
+		}
+		_, err = setEndorsement(c, stub.GetTxID(), org)
+		if err != nil {
+			return nil, err
+		}
+
+		// the initial balance is issued to the address of user
+		entry := JournalEntry{CreatedAt: data.CreatedAt}
+		entry.move(utils.WalletCoinSymbol, data.WalletBalance,
+			JournalLeg{Account: utils.IssuanceAccount, TxnType: utils.InitialBalanceTxn},
+			JournalLeg{Account: data.Address, UserID: stub.GetTxID(), TxnType: utils.InitialBalanceTxn, AddressBookLabel: "Original"})
+		err = postEntry(c, entry)
+		if err != nil {
+			return nil, err
+		}
+
 		// prepare the response body
-		responseBody := NewUserResponse{ID: stub.GetTxID(), Address: data.Address, WalletBalance: data.WalletBalance, Symbol: data.Symbol, CreatedAt: data.CreatedAt, UserAddresses: addresses, Identity: data.Identity, Secret: data.Secret + data.Identity}
+		responseBody := NewUserResponse{ID: stub.GetTxID(), Address: data.Address, WalletBalance: data.WalletBalance, Symbol: data.Symbol, CreatedAt: data.CreatedAt, UserAddresses: addresses}
 
 		// Save the data and return the response
 		return responseBody, c.State().Put(stub.GetTxID(), data)
@@ -71,26 +121,34 @@ func CreateUser(c router.Context) (interface{}, error) {
 
 // GetUser fetch the details of user
 func GetUser(c router.Context) (interface{}, error) {
-	// get the data from the request and parse it as structure
-	data := c.Param(`data`).(UserSecret)
+	// get the data from the transient map and parse it as structure
+	data := UserSecret{}
+	err := utils.GetTransient(c, utils.TransientUserKey, &data)
+	if err != nil {
+		return nil, err
+	}
 
 	// Validate the inputed data
-	err := data.Validate()
+	err = middleware.Validated(data)
 	if err != nil {
-		if _, ok := err.(validation.InternalError); ok {
-			return nil, err
-		}
-		return nil, status.ErrStatusUnprocessableEntity.WithValidationError(err.(validation.Errors))
+		return nil, err
 	}
 
-	secret := data.Secret[0:300]
+	secret := data.Secret[0:utils.SecretLength]
+	identity := data.Secret[utils.SecretLength:]
 
 	// check if user already exists or not
-	queryString := fmt.Sprintf("{\"selector\":{\"secret\":\"%s\",\"doc_type\":\"%s\"}}", secret, utils.DocTypeUser)
-	userResult, userID, err := utils.Get(c, queryString, fmt.Sprintf("User found!"))
+	queryString := fmt.Sprintf("{\"selector\":{\"secret_hash\":\"%s\",\"doc_type\":\"%s\"}}", utils.Hash(secret), utils.DocTypeUser)
+	userResult, userID, err := utils.Get(c, queryString, "User found!")
 
 	if userResult == nil {
-		return nil, status.ErrBadRequest.WithMessage(fmt.Sprintf("User does not exist in this system!"))
+		return nil, status.ErrInvalidSecret.WithMessagef("User does not exist in this system!")
+	}
+
+	private := UserPrivate{}
+	err = utils.GetPrivate(c, utils.CollectionUserPrivate, userID, &private)
+	if err != nil || private.Identity != identity {
+		return nil, status.ErrInvalidSecret.WithMessagef("User does not exist in this system!")
 	}
 
 	userData := UserResponse{}
@@ -99,6 +157,7 @@ func GetUser(c router.Context) (interface{}, error) {
 		return nil, status.ErrInternal.WithError(err)
 	}
 	userData.ID = userID
+	userData.Identity = private.Identity
 
 	userBytes, _ := json.Marshal(userData)
 
@@ -111,29 +170,18 @@ func AddAddress(c router.Context) (interface{}, error) {
 	// get the data from the request and parse it as structure
 	data := c.Param(`data`).(Address)
 
-	// Validate the inputed data
-	err := data.Validate()
+	// check the address against the blocked list
+	err := screenAddresses(c, ComplianceAlert{UserID: data.UserID}, data.Value)
 	if err != nil {
-		if _, ok := err.(validation.InternalError); ok {
-			return nil, err
-		}
-		return nil, status.ErrStatusUnprocessableEntity.WithValidationError(err.(validation.Errors))
+		return nil, err
 	}
 
 	// check if address already exists or not
 	queryString := fmt.Sprintf("{\"selector\": {\"user_addresses\": {\"$elemMatch\": {\"value\": \"%s\"}},\"doc_type\":\"%s\"}}", data.Value, utils.DocTypeUser)
-	userResult, _, err := utils.Get(c, queryString, fmt.Sprintf("User already exists with the given address %s!", data.Value))
+	userResult, _, err := utils.Get(c, queryString, "User already exists with the given address %s!", data.Value)
 
 	if userResult != nil {
-		return nil, status.ErrBadRequest.WithMessage(fmt.Sprintf("This address %s already exists in the system!", data.Value))
-	}
-
-	// check if address already exists or not
-	labelQueryString := fmt.Sprintf("{\"selector\": {\"user_addresses\": {\"$elemMatch\": {\"label\": \"%s\"}},\"_id\":{\"$ne\":\"%s\"},\"doc_type\":\"%s\"}}", data.Label, data.UserID, utils.DocTypeUser)
-	labelResult, _, err := utils.Get(c, labelQueryString, fmt.Sprintf("User already exists with the given label %s!", data.Label))
-
-	if labelResult != nil {
-		return nil, status.ErrBadRequest.WithMessage(fmt.Sprintf("This label %s has already been taken!", data.Label))
+		return nil, status.ErrAddressTaken.WithMessagef("This address %s already exists in the system!", data.Value)
 	}
 
 	address1 := Address{UserID: data.UserID, Label: data.Label, Value: data.Value}
@@ -146,6 +194,11 @@ func AddAddress(c router.Context) (interface{}, error) {
 		return nil, err
 	}
 
+	// check if label is unique among the addresses of user
+	if _, ok := findAddressByLabel(user.UserAddresses, data.Label); ok {
+		return nil, status.ErrLabelTaken.WithMessagef("This label %s has already been taken!", data.Label)
+	}
+
 	user.UserAddresses = append(user.UserAddresses, address1)
 	// prepare the response body
 	responseBody := UserResponse{ID: data.UserID, Address: user.Address, WalletBalance: user.WalletBalance, Symbol: user.Symbol, CreatedAt: user.CreatedAt, UserAddresses: user.UserAddresses, Identity: user.Identity}
@@ -157,15 +210,6 @@ func AddAddress(c router.Context) (interface{}, error) {
 func GetUsers(c router.Context) (interface{}, error) {
 	// get the data from the request and parse it as structure
 	data := c.Param(`data`).(UserId)
-
-	// Validate the inputed data
-	err := data.Validate()
-	if err != nil {
-		if _, ok := err.(validation.InternalError); ok {
-			return nil, err
-		}
-		return nil, status.ErrStatusUnprocessableEntity.WithValidationError(err.(validation.Errors))
-	}
 	stub := c.Stub()
 	queryString := fmt.Sprintf("{\"selector\":{\"_id\":{\"$ne\":\"%s\"},\"doc_type\":\"%s\"}}", data.ID, utils.DocTypeUser)
 	resultsIterator, err := stub.GetQueryResult(queryString)
@@ -214,25 +258,14 @@ func GetAssets(c router.Context) (interface{}, error) {
 	// get the data from the request and parse it as structure
 	data := c.Param(`data`).(UserId)
 
-	// Validate the inputed data
-	err := data.Validate()
-	if err != nil {
-
-		if _, ok := err.(validation.InternalError); ok {
-			return nil, err
-		}
-		return nil, status.ErrStatusUnprocessableEntity.WithValidationError(err.(validation.Errors))
-	}
-
-	stub := c.Stub()
 	queryUserString := fmt.Sprintf("{\"selector\":{\"_id\":\"%s\",\"doc_type\":\"%s\"}}", data.ID, utils.DocTypeUser)
-	userData, _, err1 := utils.Get(c, queryUserString, fmt.Sprintf("User %s does not exist!", data.ID))
+	userData, _, err1 := utils.Get(c, queryUserString, "User %s does not exist!", data.ID)
 	if err1 != nil {
 		return nil, err1
 	}
 
 	user := User{}
-	err = json.Unmarshal(userData, &user)
+	err := json.Unmarshal(userData, &user)
 	if err != nil {
 		return nil, status.ErrInternal.WithError(err)
 	}
@@ -243,69 +276,66 @@ func GetAssets(c router.Context) (interface{}, error) {
 	}
 
 	queryString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"doc_type\":\"%s\"}}", data.ID, utils.DocTypeAsset)
-	resultsIterator, err := stub.GetQueryResult(queryString)
+	assetResults, err := utils.GetAll(c, queryString)
 	if err != nil {
-		fmt.Println(err)
-		return nil, status.ErrInternal.WithError(err)
+		return nil, err
 	}
-	defer resultsIterator.Close()
 
-	queryTransactionsString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"doc_type\":\"%s\"},\"sort\":[{\"created_at\":\"desc\"}]}", data.ID, utils.DocTypeTransaction)
-	resultsIterator2, err := stub.GetQueryResult(queryTransactionsString)
+	var assets []Asset
+	for _, assetResult := range assetResults {
+		asset := Asset{}
+		err = json.Unmarshal(assetResult.Value, &asset)
+		if err != nil {
+			return nil, status.ErrInternal.WithError(err)
+		}
+		// assets created before sub-accounts belong to the primary address
+		if asset.Address == "" {
+			asset.Address = user.Address
+		}
+		assets = append(assets, asset)
+	}
+	_, err = allocateBalance(&user)
+	if err != nil {
+		return nil, err
+	}
+	assetsBytes, _ := json.Marshal(aggregateAssets(assets))
+
+	// the holds reserve a part of the balance and assets of the addresses
+	holds, err := activeHolds(c, data.ID)
 	if err != nil {
-		fmt.Println(err)
-		return nil, status.ErrInternal.WithError(err)
+		return nil, err
+	}
+	accounts, err := applyHolds(subAccounts(user, assets), holds)
+	if err != nil {
+		return nil, err
+	}
+	addressesBytes, _ := json.Marshal(accounts)
+
+	transactions, err := userTransactions(c, data.ID, "")
+	if err != nil {
+		return nil, err
+	}
+	err = labelTransactions(c, data.ID, transactions)
+	if err != nil {
+		return nil, err
 	}
-	defer resultsIterator2.Close()
+	transactionsBytes, _ := json.Marshal(transactions)
 
 	// buffer is a JSON array containing QueryResults
 	var buffer bytes.Buffer
 	buffer.WriteString("{")
-	buffer.WriteString("\"assets\": [")
-	aArrayMemberAlreadyWritten := false
-	for resultsIterator.HasNext() {
-		queryResponse, err := resultsIterator.Next()
-		if err != nil {
-			return nil, status.ErrInternal.WithError(err)
-		}
-
-		// Add a comma before array members, suppress it for the first array member
-		if aArrayMemberAlreadyWritten == true {
-			buffer.WriteString(",")
-		}
-		buffer.WriteString(string(queryResponse.Value))
-		aArrayMemberAlreadyWritten = true
-	}
-	buffer.WriteString("],")
+	buffer.WriteString("\"assets\": ")
+	buffer.WriteString(string(assetsBytes))
+	buffer.WriteString(",")
+	buffer.WriteString("\"addresses\": ")
+	buffer.WriteString(string(addressesBytes))
+	buffer.WriteString(",")
 	buffer.WriteString("\"wallet_balance\": ")
 	buffer.WriteString(string(resBytes))
 	buffer.WriteString(",")
-	buffer.WriteString("\"transactions\": [")
-
-	bArrayMemberAlreadyWritten := false
-	for resultsIterator2.HasNext() {
-		queryResponse2, err := resultsIterator2.Next()
-		if err != nil {
-			return nil, status.ErrInternal.WithError(err)
-		}
-
-		txnData := TransactionResponse{}
-		err3 := json.Unmarshal(queryResponse2.Value, &txnData)
-		if err3 != nil {
-			return nil, status.ErrInternal.WithError(err3)
-		}
-
-		txnData.ID = queryResponse2.Key
-		txnDataBytes, _ := json.Marshal(txnData)
-
-		// Add a comma before array members, suppress it for the first array member
-		if bArrayMemberAlreadyWritten == true {
-			buffer.WriteString(",")
-		}
-		buffer.WriteString(string(txnDataBytes))
-		bArrayMemberAlreadyWritten = true
-	}
-	buffer.WriteString("]}")
+	buffer.WriteString("\"transactions\": ")
+	buffer.WriteString(string(transactionsBytes))
+	buffer.WriteString("}")
 
 	//return the response
 	return buffer.Bytes(), nil
@@ -319,41 +349,52 @@ func AddAsset(c router.Context) (interface{}, error) {
 	// set the default values for the fields
 	data.DocType = utils.DocTypeAsset
 
-	// Validate the inputed data
-	err := data.Validate()
-	if err != nil {
-		if _, ok := err.(validation.InternalError); ok {
-			return nil, err
-		}
-		return nil, status.ErrStatusUnprocessableEntity.WithValidationError(err.(validation.Errors))
-	}
-
 	stub := c.Stub()
 	txID := stub.GetTxID()
 	userAsBytes, _ := stub.GetState(data.UserID)
 	user := User{}
 
-	err = json.Unmarshal(userAsBytes, &user)
+	err := json.Unmarshal(userAsBytes, &user)
 	if err != nil {
 		return nil, err
 	}
 
-	if user.WalletBalance < utils.AddAssetFee {
-		return nil, status.ErrInternal.WithMessage(fmt.Sprintf("You don't have enough coins to purchase this asset."))
+	// the fee is paid from and the asset is held by the primary address
+	_, err = allocateBalance(&user)
+	if err != nil {
+		return nil, err
+	}
+	primary, err := spendingAddress(user, "")
+	if err != nil {
+		return nil, err
+	}
+	// the coins reserved by the holds of the address can't pay the fee
+	available, err := availableQuantity(c, data.UserID, user.UserAddresses[primary].Value, utils.WalletCoinSymbol, user.UserAddresses[primary].Balance, "")
+	if err != nil {
+		return nil, err
+	}
+	fee := amount.Units(utils.AddAssetFee)
+	if available.Cmp(fee) < 0 {
+		return nil, status.ErrInsufficientBalance.WithMessagef("You don't have enough coins to purchase this asset.")
 	}
+	data.Quantity, err = data.Quantity.For(data.Code)
+	if err != nil {
+		return nil, err
+	}
+	data.Address = user.Address
 
 	// check asset code already exists
 	queryString := fmt.Sprintf("{\"selector\":{\"code\":\"%s\",\"doc_type\":\"%s\"}}", data.Code, utils.DocTypeAsset)
 	asset, _, err := utils.Get(c, queryString, "")
 	if asset != nil {
-		return nil, status.ErrBadRequest.WithMessage(fmt.Sprintf("Symbol %s already exists!", data.Code))
+		return nil, status.ErrAssetCodeTaken.WithMessagef("Symbol %s already exists!", data.Code)
 	}
 
 	// check asset label already exists
 	queryString1 := fmt.Sprintf("{\"selector\":{\"label\":\"%s\",\"doc_type\":\"%s\"}}", data.Label, utils.DocTypeAsset)
 	assetLabel, _, err := utils.Get(c, queryString1, "")
 	if assetLabel != nil {
-		return nil, status.ErrBadRequest.WithMessage(fmt.Sprintf("Name %s already exists!", data.Label))
+		return nil, status.ErrAssetNameTaken.WithMessagef("Name %s already exists!", data.Label)
 	}
 
 	err = c.State().Put(txID, data)
@@ -361,12 +402,38 @@ func AddAsset(c router.Context) (interface{}, error) {
 		return nil, err
 	}
 
-	user.WalletBalance = user.WalletBalance - utils.AddAssetFee
+	// the organization of the issuer must endorse the changes of the asset
+	org, err := creatorOrg(c)
+	if err != nil {
+		return nil, err
+	}
+	_, err = setEndorsement(c, txID, org)
+	if err != nil {
+		return nil, err
+	}
 
-	createdAt := time.Now().Format(time.RFC3339)
-	// add asset transaction
-	var addAssetTransaction = Transaction{UserID: data.UserID, Type: utils.Send, Code: utils.WalletCoinSymbol, AssetLabel: data.Label, Quantity: utils.AddAssetFee, DocType: utils.DocTypeTransaction, CreatedAt: createdAt, AddressValue: "", LabelValue: "", AddressBookLabel: "Original", TxnType: utils.AssetCreatedTxn}
-	err = c.State().Put(txID+strconv.Itoa(1), addAssetTransaction)
+	// the transfers of the asset are free until the issuer restricts them
+	updatedAt, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	policy := AssetPolicy{Code: data.Code, IssuerID: data.UserID, IssuerOrg: org, DocType: utils.DocTypeAssetPolicy, UpdatedAt: updatedAt.Format(time.RFC3339)}
+	err = c.State().Put([]string{utils.DocTypeAssetPolicy, data.Code}, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = debit(&user, primary, fee)
+	if err != nil {
+		return nil, err
+	}
+
+	// the asset is issued to and the fee is paid by the address of user
+	holder := JournalLeg{Account: user.Address, UserID: data.UserID, TxnType: utils.AssetCreatedTxn, AssetLabel: data.Label, AddressBookLabel: "Original"}
+	entry := JournalEntry{}
+	entry.move(data.Code, data.Quantity, JournalLeg{Account: utils.IssuanceAccount, TxnType: utils.AssetCreatedTxn, AssetLabel: data.Label}, holder)
+	entry.move(utils.WalletCoinSymbol, fee, holder, JournalLeg{Account: utils.FeeAccount, TxnType: utils.AssetCreatedTxn, AssetLabel: data.Label})
+	err = postEntry(c, entry)
 	if err != nil {
 		return nil, err
 	}
@@ -382,27 +449,18 @@ func CheckAsset(c router.Context) (interface{}, error) {
 	// get the data from the request and parse it as structure
 	data := c.Param(`data`).(CheckAssetStruct)
 
-	// Validate the inputed data
-	err := data.Validate()
-	if err != nil {
-		if _, ok := err.(validation.InternalError); ok {
-			return nil, err
-		}
-		return nil, status.ErrStatusUnprocessableEntity.WithValidationError(err.(validation.Errors))
-	}
-
 	// check already exists
 	queryString := fmt.Sprintf("{\"selector\":{\"code\":\"%s\",\"doc_type\":\"%s\"}}", data.Code, utils.DocTypeAsset)
-	asset, _, err := utils.Get(c, queryString, "")
+	asset, _, _ := utils.Get(c, queryString, "")
 	if asset != nil {
-		return nil, status.ErrBadRequest.WithMessage(fmt.Sprintf("Symbol %s already exists!", data.Code))
+		return nil, status.ErrAssetCodeTaken.WithMessagef("Symbol %s already exists!", data.Code)
 	}
 
 	// check already exists
 	queryString1 := fmt.Sprintf("{\"selector\":{\"label\":\"%s\",\"doc_type\":\"%s\"}}", data.Label, utils.DocTypeAsset)
-	asset1, _, err := utils.Get(c, queryString1, "")
+	asset1, _, _ := utils.Get(c, queryString1, "")
 	if asset1 != nil {
-		return nil, status.ErrBadRequest.WithMessage(fmt.Sprintf("Name %s already exists!", data.Label))
+		return nil, status.ErrAssetNameTaken.WithMessagef("Name %s already exists!", data.Label)
 	}
 
 	responseBody := utils.ResponseMessage{Message: "Both name and symbol are available."}
@@ -416,38 +474,57 @@ func TransferAsset(c router.Context) (interface{}, error) {
 	// get the data from the request and parse it as structure
 	data := c.Param(`data`).(GetTransaction)
 
-	// Validate the inputed data
-	err := data.Validate()
+	// the receiver may be given by its registered name
+	to, err := resolveRecipient(c, data.To)
 	if err != nil {
-		if _, ok := err.(validation.InternalError); ok {
-			return nil, err
-		}
-		return nil, status.ErrStatusUnprocessableEntity.WithValidationError(err.(validation.Errors))
+		return nil, err
 	}
+	data.To = to
 
+	return transferAsset(c, data, transferOptions{})
+}
+
+// transferOptions the options of a transfer, consented, approved and memo only apply to the assets
+type transferOptions struct {
+	// the receiver has asked for the asset, so it is not held for acceptance
+	consented bool
+	// the issuer has approved the transfer of the restricted asset
+	approved bool
+	// the memo which was encrypted when the transfer was requested
+	memo *transferMemo
+	// the hold which the transfer captures, its quantity is available to the transfer
+	hold string
+	// the payment request which the transfer pays, it is settled when the issuer approves
+	paymentRequest string
+}
+
+// transferAsset transfers the asset, it is shared by TransferAsset, the payment requests and the
+// approval of the restricted transfers. The asset is pending when the receiver requires acceptance
+// and has not consented to the transfer
+func transferAsset(c router.Context, data GetTransaction, options transferOptions) (interface{}, error) {
 	// check receiver data
 	queryRecevierString := fmt.Sprintf("{\"selector\": {\"user_addresses\": {\"$elemMatch\": {\"value\": \"%s\"}},\"doc_type\":\"%s\"}}", data.To, utils.DocTypeUser)
-	receiverData, receiverID, err5 := utils.Get(c, queryRecevierString, fmt.Sprintf("Receiver %s does not exist!", data.To))
+	receiverData, receiverID, err5 := utils.Get(c, queryRecevierString, "Receiver %s does not exist!", data.To)
 	if err5 != nil {
 		return nil, err5
 	}
 
 	receiver := User{}
-	err = json.Unmarshal(receiverData, &receiver)
+	err := json.Unmarshal(receiverData, &receiver)
 	if err != nil {
 		return nil, status.ErrInternal.WithError(err)
 	}
 
-	var receiverOwnLabel string
-	for i := range receiver.UserAddresses {
-		if receiver.UserAddresses[i].Value == data.To {
-			receiverOwnLabel = receiver.UserAddresses[i].Label
-		}
+	// retired addresses can no longer receive funds
+	receiverAddress := addressByValue(receiver.UserAddresses, data.To)
+	if receiverAddress.Retired {
+		return nil, status.ErrAddressRetired.WithMessagef("Address %s has been retired!", data.To)
 	}
+	receiverOwnLabel := receiverAddress.Label
 
 	// check sender data
 	querySenderString := fmt.Sprintf("{\"selector\":{\"_id\":\"%s\",\"doc_type\":\"%s\"}}", data.From, utils.DocTypeUser)
-	senderData, _, err6 := utils.Get(c, querySenderString, fmt.Sprintf("You account %s does not exist!", data.From))
+	senderData, _, err6 := utils.Get(c, querySenderString, "You account %s does not exist!", data.From)
 	if err6 != nil {
 		return nil, err6
 	}
@@ -457,19 +534,46 @@ func TransferAsset(c router.Context) (interface{}, error) {
 		return nil, status.ErrInternal.WithError(err)
 	}
 
-	if sender.WalletBalance < utils.TransferAssetFee {
-		return nil, status.ErrInternal.WithMessage(fmt.Sprintf("You don't have enough coins to transfer the asset."))
+	// the asset and the fee are taken from the selected address of sender
+	_, err = allocateBalance(&sender)
+	if err != nil {
+		return nil, err
+	}
+	from, err := spendingAddress(sender, data.FromAddress)
+	if err != nil {
+		return nil, err
+	}
+	fromAddress := sender.UserAddresses[from].Value
+
+	// the coins and the asset reserved by the holds of the address can't be spent
+	availableBalance, err := availableQuantity(c, data.From, fromAddress, utils.WalletCoinSymbol, sender.UserAddresses[from].Balance, options.hold)
+	if err != nil {
+		return nil, err
+	}
+	fee := amount.Units(utils.TransferAssetFee)
+	if availableBalance.Cmp(fee) < 0 {
+		return nil, status.ErrInsufficientBalance.WithMessagef("You don't have enough coins to transfer the asset.")
+	}
+	data.Quantity, err = data.Quantity.For(data.Code)
+	if err != nil {
+		return nil, err
 	}
 
 	for i := range sender.UserAddresses {
 		if sender.UserAddresses[i].Value == data.To {
-			return nil, status.ErrInternal.WithMessage(fmt.Sprintf("You can't transfer asset to yourself!"))
+			return nil, status.ErrSelfTransfer.WithMessagef("You can't transfer asset to yourself!")
 		}
 	}
 
+	// check both parties against the blocked list
+	alert := ComplianceAlert{UserID: data.From, Counterparty: data.To, Code: data.Code, Quantity: data.Quantity}
+	err = screenAddresses(c, alert, append([]string{data.To}, addressValues(sender.UserAddresses)...)...)
+	if err != nil {
+		return nil, err
+	}
+
 	// check sender asset data
-	queryString := fmt.Sprintf("{\"selector\":{\"code\":\"%s\",\"user_id\":\"%s\",\"doc_type\":\"%s\"}}", data.Code, data.From, utils.DocTypeAsset)
-	senderAssetData, senderAssetKey, err2 := utils.Get(c, queryString, fmt.Sprintf("Symbol %s does not exist!", data.Code))
+	senderAssetData, senderAssetKey, err2 := getAddressAsset(c, data.From, fromAddress, data.Code)
 	if senderAssetData == nil {
 		return nil, err2
 	}
@@ -478,26 +582,59 @@ func TransferAsset(c router.Context) (interface{}, error) {
 	if err != nil {
 		return nil, status.ErrInternal.WithError(err)
 	}
-	if data.Quantity > senderAsset.Quantity {
-		return nil, status.ErrInternal.WithMessage(fmt.Sprintf("Quantity should be less or equal to %d", senderAsset.Quantity))
+	availableAsset, err := availableQuantity(c, data.From, fromAddress, data.Code, senderAsset.Quantity, options.hold)
+	if err != nil {
+		return nil, err
+	}
+	if data.Quantity.Cmp(availableAsset) > 0 {
+		return nil, status.ErrInsufficientAsset.WithMessagef("Quantity should be less or equal to %s", availableAsset)
+	}
+
+	policy, err := getAssetPolicy(c, data.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the addresses on the allowlist may hold the asset, besides its issuer
+	if policy.Allowlisted && receiverID != policy.IssuerID {
+		eligible, err := isAllowlisted(c, data.Code, data.To)
+		if err != nil {
+			return nil, err
+		}
+		if !eligible {
+			return nil, status.ErrNotAllowlisted.WithMessagef("Receiver %s is not on the allowlist of %s!", data.To, data.Code)
+		}
+	}
+
+	// the issuer approves each change of ownership of a restricted asset, except of its own
+	if policy.Restricted && policy.IssuerID != data.From && !options.approved {
+		return requestRestrictedTransfer(c, data, fromAddress, sender, receiverID, receiver, options.paymentRequest)
+	}
+
+	// flag suspicious activity without blocking the transfer
+	err = evaluateRules(c, alert, utils.AssetTxnType)
+	if err != nil {
+		return nil, err
 	}
 
 	stub := c.Stub()
 	txID := stub.GetTxID()
-	data.CreatedAt = time.Now().Format(time.RFC3339)
+	createdAt, err := utils.TxTime(c)
+	if err != nil {
+		return nil, err
+	}
+	data.CreatedAt = createdAt.Format(time.RFC3339)
 
 	var receiverLabel, senderLabel string
-	// check label of receiver in sender's address book
-	receiverLabelString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"address\":\"%s\",\"label\":\"%s\",\"doc_type\":\"%s\"}}", data.From, data.To, data.Label, utils.DocTypeAddressBook)
-	receiverLabelData, _, err6 := utils.Get(c, receiverLabelString, fmt.Sprintf("Label of receiver does not exist!"))
+	// check label of receiver in sender's address book, the contact keeps its label
+	receiverLabelData, _, err6 := getContact(c, data.From, data.To)
 
-	//If label does not exist in address book then save it into db
+	//If receiver does not exist in address book then save it into db
 	if receiverLabelData == nil {
 		// check if label is unique
-		checkUniqueString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"label\":\"%s\",\"doc_type\":\"%s\"}}", data.From, data.Label, utils.DocTypeAddressBook)
-		uniqueLabelData, _, err := utils.Get(c, checkUniqueString, fmt.Sprintf("This label already exists!"))
+		uniqueLabelData, _, err := getContactByLabel(c, data.From, data.Label)
 		if uniqueLabelData != nil {
-			return nil, status.ErrInternal.WithMessage(fmt.Sprintf("This label already exists!"))
+			return nil, status.ErrLabelTaken.WithMessagef("This label already exists!")
 		}
 
 		labelTxn := AddressBook{UserID: data.From, Address: data.To, Label: data.Label, DocType: utils.DocTypeAddressBook}
@@ -517,9 +654,12 @@ func TransferAsset(c router.Context) (interface{}, error) {
 		receiverLabel = addressLabel.Label
 	}
 
+	// label of the sender address at the time of the transfer
+	senderOwnLabel := sender.UserAddresses[from].Label
+
 	// check label of sender in receiver's address book
-	senderLabelString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"address\":\"%s\",\"doc_type\":\"%s\"}}", receiver.UserAddresses[0].UserID, sender.Address, utils.DocTypeAddressBook)
-	senderLabelData, _, err6 := utils.Get(c, senderLabelString, fmt.Sprintf("Label of sender does not exist!"))
+	senderLabelString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"address\":\"%s\",\"doc_type\":\"%s\"}}", receiverID, fromAddress, utils.DocTypeAddressBook)
+	senderLabelData, _, err6 := utils.Get(c, senderLabelString, "Label of sender does not exist!")
 
 	//If label does not exist in address book
 	if senderLabelData == nil {
@@ -534,57 +674,57 @@ func TransferAsset(c router.Context) (interface{}, error) {
 		senderLabel = addressLabel1.Label
 	}
 
-	// sender transactions
-	var senderTransaction = Transaction{UserID: data.From, Type: utils.Send, Code: data.Code, AssetLabel: senderAsset.Label, Quantity: data.Quantity, DocType: utils.DocTypeTransaction, CreatedAt: data.CreatedAt, AddressValue: data.To, LabelValue: receiverOwnLabel, AddressBookLabel: receiverLabel, TxnType: utils.AssetTxnType}
-	err = c.State().Put(txID+strconv.Itoa(1), senderTransaction)
+	memo := transferMemo{}
+	if options.memo != nil {
+		memo = *options.memo
+	} else {
+		memo, err = getTransferMemo(c, data.Memo, sender, receiverID, receiver)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the asset goes from sender to receiver and the fee from sender to the fee account
+	entry := JournalEntry{CreatedAt: data.CreatedAt}
+	receiverLeg := JournalLeg{Account: data.To, UserID: receiverID, TxnType: utils.AssetTxnType, AssetLabel: senderAsset.Label, AddressValue: fromAddress, LabelValue: senderOwnLabel, AddressBookLabel: senderLabel, Memo: memo.Text, EncryptedMemo: memo.Receiver}
+	pending := receiver.RequireAcceptance && !options.consented
+	if pending {
+		// the asset waits in the pending account of the receiver address until it is accepted
+		receiverLeg = JournalLeg{Account: utils.PendingAccount + data.To, TxnType: utils.AssetTxnType, AssetLabel: senderAsset.Label}
+	}
+	entry.move(data.Code, data.Quantity,
+		JournalLeg{Account: fromAddress, UserID: data.From, TxnType: utils.AssetTxnType, AssetLabel: senderAsset.Label, AddressValue: data.To, LabelValue: receiverOwnLabel, AddressBookLabel: receiverLabel, Memo: memo.Text, EncryptedMemo: memo.Sender},
+		receiverLeg)
+	entry.move(utils.WalletCoinSymbol, fee,
+		JournalLeg{Account: fromAddress, UserID: data.From, TxnType: utils.AssetTransferredTxn, AssetLabel: senderAsset.Label, AddressValue: data.To, LabelValue: receiverOwnLabel, AddressBookLabel: receiverLabel},
+		JournalLeg{Account: utils.FeeAccount, TxnType: utils.AssetTransferredTxn, AssetLabel: senderAsset.Label})
+	err = postEntry(c, entry)
 	if err != nil {
 		return nil, err
 	}
 
-	// receiver transactions
-	var receiveTransaction = Transaction{UserID: receiverID, Type: utils.Receive, Code: data.Code, AssetLabel: senderAsset.Label, Quantity: data.Quantity, DocType: utils.DocTypeTransaction, CreatedAt: data.CreatedAt, AddressValue: sender.Address, LabelValue: "Original", AddressBookLabel: senderLabel, TxnType: utils.AssetTxnType}
-	err = c.State().Put(txID+strconv.Itoa(2), receiveTransaction)
+	senderAsset.Quantity, err = senderAsset.Quantity.Sub(data.Quantity)
 	if err != nil {
 		return nil, err
 	}
 
-	senderAsset.Quantity = senderAsset.Quantity - data.Quantity
-
 	// update sender asset data
 	err = c.State().Put(senderAssetKey, senderAsset)
 	if err != nil {
 		return nil, err
 	}
 
-	// check receiver asset data
-	queryReceiverDataString := fmt.Sprintf("{\"selector\":{\"code\":\"%s\",\"user_id\":\"%s\",\"doc_type\":\"%s\"}}", data.Code, receiverID, utils.DocTypeAsset)
-	receiverAssetData, receiveAssetKey, _ := utils.Get(c, queryReceiverDataString, "")
-	if receiverAssetData == nil {
-		// add to receiver asset
-		var receiveAsset = Asset{UserID: receiverID, Code: data.Code, Label: senderAsset.Label, Quantity: data.Quantity, DocType: utils.DocTypeAsset}
-		err = c.State().Put(txID+strconv.Itoa(3), receiveAsset)
-		if err != nil {
-			return nil, err
-		}
+	if pending {
+		transfer := PendingTransfer{TransferID: txID, UserID: receiverID, Address: data.To, SenderID: data.From, SenderAddress: fromAddress, Code: data.Code, AssetLabel: senderAsset.Label, Quantity: data.Quantity, LabelValue: senderOwnLabel, AddressBookLabel: senderLabel, Memo: memo.Text, EncryptedMemo: memo.Receiver}
+		err = holdTransfer(c, transfer)
 	} else {
-		receiverAsset := Asset{}
-		err = json.Unmarshal(receiverAssetData, &receiverAsset)
-		if err != nil {
-			return nil, status.ErrInternal.WithError(err)
-		}
-		// update receiver asset
-		receiverAsset.Quantity = receiverAsset.Quantity + data.Quantity
-		err = c.State().Put(receiveAssetKey, receiverAsset)
-		if err != nil {
-			return nil, err
-		}
+		err = creditAsset(c, txID+strconv.Itoa(3), Asset{UserID: receiverID, Address: data.To, Code: data.Code, Label: senderAsset.Label, Quantity: data.Quantity})
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	sender.WalletBalance = sender.WalletBalance - utils.TransferAssetFee
-
-	// Transfer asset transaction
-	var transferAssetTransaction = Transaction{UserID: data.From, Type: utils.Send, Code: utils.WalletCoinSymbol, AssetLabel: senderAsset.Label, Quantity: utils.TransferAssetFee, DocType: utils.DocTypeTransaction, CreatedAt: data.CreatedAt, AddressValue: data.To, LabelValue: receiverOwnLabel, AddressBookLabel: receiverLabel, TxnType: utils.AssetTransferredTxn}
-	err = c.State().Put(txID+strconv.Itoa(4), transferAssetTransaction)
+	err = debit(&sender, from, fee)
 	if err != nil {
 		return nil, err
 	}
@@ -595,36 +735,72 @@ func TransferAsset(c router.Context) (interface{}, error) {
 	return responseBody, c.State().Put(data.From, sender)
 }
 
+// creditAsset adds the quantity of the asset to the asset of its user at its address, the asset
+// is saved under the key when the address does not hold the asset yet
+func creditAsset(c router.Context, key string, asset Asset) error {
+	assetData, assetKey, _ := getAddressAsset(c, asset.UserID, asset.Address, asset.Code)
+	if assetData == nil {
+		asset.DocType = utils.DocTypeAsset
+		err := c.State().Put(key, asset)
+		if err != nil {
+			return err
+		}
+		return endorseHolding(c, key, asset.Code)
+	}
+
+	existing := Asset{}
+	err := json.Unmarshal(assetData, &existing)
+	if err != nil {
+		return status.ErrInternal.WithError(err)
+	}
+	existing.Quantity, err = existing.Quantity.Add(asset.Quantity)
+	if err != nil {
+		return err
+	}
+	return c.State().Put(assetKey, existing)
+}
+
 // TransferBalance to transfer asset to another user
 func TransferBalance(c router.Context) (interface{}, error) {
 	// get the data from the request and parse it as structure
 	data := c.Param(`data`).(SendBalance)
 
-	// Validate the inputed data
-	err := data.Validate()
+	// the receiver may be given by its registered name
+	to, err := resolveRecipient(c, data.To)
 	if err != nil {
-		if _, ok := err.(validation.InternalError); ok {
-			return nil, err
-		}
-		return nil, status.ErrStatusUnprocessableEntity.WithValidationError(err.(validation.Errors))
+		return nil, err
 	}
+	data.To = to
+
+	return transferBalance(c, data, transferOptions{})
+}
 
+// transferBalance transfers the coins, it is shared by TransferBalance, the payment requests and the
+// capture of the holds
+func transferBalance(c router.Context, data SendBalance, options transferOptions) (interface{}, error) {
 	// check receiver data
 	queryRecevierString := fmt.Sprintf("{\"selector\": {\"user_addresses\": {\"$elemMatch\": {\"value\": \"%s\"}},\"doc_type\":\"%s\"}}", data.To, utils.DocTypeUser)
-	receiverData, _, err5 := utils.Get(c, queryRecevierString, fmt.Sprintf("Receiver %s does not exist!", data.To))
+	receiverData, receiverID, err5 := utils.Get(c, queryRecevierString, "Receiver %s does not exist!", data.To)
 	if err5 != nil {
 		return nil, err5
 	}
 
 	receiver := User{}
-	err = json.Unmarshal(receiverData, &receiver)
+	err := json.Unmarshal(receiverData, &receiver)
 	if err != nil {
 		return nil, status.ErrInternal.WithError(err)
 	}
 
+	// retired addresses can no longer receive funds
+	receiverAddress := addressByValue(receiver.UserAddresses, data.To)
+	if receiverAddress.Retired {
+		return nil, status.ErrAddressRetired.WithMessagef("Address %s has been retired!", data.To)
+	}
+	receiverOwnLabel := receiverAddress.Label
+
 	// check sender data
 	querySenderString := fmt.Sprintf("{\"selector\":{\"_id\":\"%s\",\"doc_type\":\"%s\"}}", data.From, utils.DocTypeUser)
-	senderData, _, err6 := utils.Get(c, querySenderString, fmt.Sprintf("You account %s does not exist!", data.From))
+	senderData, _, err6 := utils.Get(c, querySenderString, "You account %s does not exist!", data.From)
 	if err6 != nil {
 		return nil, err6
 	}
@@ -636,29 +812,60 @@ func TransferBalance(c router.Context) (interface{}, error) {
 
 	for i := range sender.UserAddresses {
 		if sender.UserAddresses[i].Value == data.To {
-			return nil, status.ErrInternal.WithMessage(fmt.Sprintf("You can't transfer coins to yourself!"))
+			return nil, status.ErrSelfTransfer.WithMessagef("You can't transfer coins to yourself!")
 		}
 	}
+	data.Quantity, err = data.Quantity.For(utils.WalletCoinSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	// check both parties against the blocked list
+	alert := ComplianceAlert{UserID: data.From, Counterparty: data.To, Code: utils.WalletCoinSymbol, Quantity: data.Quantity}
+	err = screenAddresses(c, alert, append([]string{data.To}, addressValues(sender.UserAddresses)...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// flag suspicious activity without blocking the transfer
+	err = evaluateRules(c, alert, utils.CoinTxnType)
+	if err != nil {
+		return nil, err
+	}
 
-	if data.Quantity > sender.WalletBalance {
-		return nil, status.ErrInternal.WithMessage(fmt.Sprintf("Quantity should be less or equal to %d", sender.WalletBalance))
+	// the coins are taken from the selected address of sender
+	_, err = allocateBalance(&sender)
+	if err != nil {
+		return nil, err
+	}
+	from, err := spendingAddress(sender, data.FromAddress)
+	if err != nil {
+		return nil, err
+	}
+	fromAddress := sender.UserAddresses[from].Value
+
+	// the coins reserved by the holds of the address can't be spent
+	available, err := availableQuantity(c, data.From, fromAddress, utils.WalletCoinSymbol, sender.UserAddresses[from].Balance, options.hold)
+	if err != nil {
+		return nil, err
+	}
+	if data.Quantity.Cmp(available) > 0 {
+		return nil, status.ErrInsufficientBalance.WithMessagef("Quantity should be less or equal to %s", available)
 	}
 
 	stub := c.Stub()
 	txID := stub.GetTxID()
 
 	var receiverLabel, senderLabel string
-	// check label of receiver in sender's address book
-	receiverLabelString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"address\":\"%s\",\"label\":\"%s\",\"doc_type\":\"%s\"}}", data.From, data.To, data.Label, utils.DocTypeAddressBook)
-	receiverLabelData, _, err6 := utils.Get(c, receiverLabelString, fmt.Sprintf("Label of receiver does not exist!"))
+	// check label of receiver in sender's address book, the contact keeps its label
+	receiverLabelData, _, err6 := getContact(c, data.From, data.To)
 
-	//If label does not exist in address book then save it into db
+	//If receiver does not exist in address book then save it into db
 	if receiverLabelData == nil {
 		// check if label is unique
-		checkUniqueString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"label\":\"%s\",\"doc_type\":\"%s\"}}", data.From, data.Label, utils.DocTypeAddressBook)
-		uniqueLabelData, _, err := utils.Get(c, checkUniqueString, fmt.Sprintf("This label already exists!"))
+		uniqueLabelData, _, err := getContactByLabel(c, data.From, data.Label)
 		if uniqueLabelData != nil {
-			return nil, status.ErrInternal.WithMessage(fmt.Sprintf("This label already exists!"))
+			return nil, status.ErrLabelTaken.WithMessagef("This label already exists!")
 		}
 
 		labelTxn := AddressBook{UserID: data.From, Address: data.To, Label: data.Label, DocType: utils.DocTypeAddressBook}
@@ -678,9 +885,12 @@ func TransferBalance(c router.Context) (interface{}, error) {
 		receiverLabel = addressLabel.Label
 	}
 
+	// label of the sender address at the time of the transfer
+	senderOwnLabel := sender.UserAddresses[from].Label
+
 	// check label of sender in receiver's address book
-	senderLabelString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"address\":\"%s\",\"doc_type\":\"%s\"}}", receiver.UserAddresses[0].UserID, sender.Address, utils.DocTypeAddressBook)
-	senderLabelData, _, err6 := utils.Get(c, senderLabelString, fmt.Sprintf("Label of sender does not exist!"))
+	senderLabelString := fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"address\":\"%s\",\"doc_type\":\"%s\"}}", receiverID, fromAddress, utils.DocTypeAddressBook)
+	senderLabelData, _, err6 := utils.Get(c, senderLabelString, "Label of sender does not exist!")
 
 	//If label does not exist in address book
 	if senderLabelData == nil {
@@ -695,31 +905,42 @@ func TransferBalance(c router.Context) (interface{}, error) {
 		senderLabel = addressLabel1.Label
 	}
 
-	createdAt := time.Now().Format(time.RFC3339)
-	// sender transactions
-	var senderTransaction = Transaction{UserID: data.From, Type: utils.Send, Code: utils.WalletCoinSymbol, Quantity: data.Quantity, DocType: utils.DocTypeTransaction, CreatedAt: createdAt, AddressValue: data.To, LabelValue: "", AddressBookLabel: receiverLabel, TxnType: utils.CoinTxnType}
-	err = c.State().Put(txID+strconv.Itoa(1), senderTransaction)
+	memo, err := getTransferMemo(c, data.Memo, sender, receiverID, receiver)
 	if err != nil {
 		return nil, err
 	}
 
-	// receiver transactions
-	var receiveTransaction = Transaction{UserID: receiver.UserAddresses[0].UserID, Type: utils.Receive, Code: utils.WalletCoinSymbol, Quantity: data.Quantity, DocType: utils.DocTypeTransaction, CreatedAt: createdAt, AddressValue: sender.Address, LabelValue: "Original", AddressBookLabel: senderLabel, TxnType: utils.CoinTxnType}
-	err = c.State().Put(txID+strconv.Itoa(2), receiveTransaction)
+	// the coins go from sender to receiver
+	entry := JournalEntry{}
+	entry.move(utils.WalletCoinSymbol, data.Quantity,
+		JournalLeg{Account: fromAddress, UserID: data.From, TxnType: utils.CoinTxnType, AddressValue: data.To, LabelValue: receiverOwnLabel, AddressBookLabel: receiverLabel, Memo: memo.Text, EncryptedMemo: memo.Sender},
+		JournalLeg{Account: data.To, UserID: receiverID, TxnType: utils.CoinTxnType, AddressValue: fromAddress, LabelValue: senderOwnLabel, AddressBookLabel: senderLabel, Memo: memo.Text, EncryptedMemo: memo.Receiver})
+	err = postEntry(c, entry)
 	if err != nil {
 		return nil, err
 	}
 
 	// update sender wallet
-	sender.WalletBalance = sender.WalletBalance - data.Quantity
+	err = debit(&sender, from, data.Quantity)
+	if err != nil {
+		return nil, err
+	}
 	err = c.State().Put(data.From, sender)
 	if err != nil {
 		return nil, err
 	}
 
-	// update receiver wallet
-	receiver.WalletBalance = receiver.WalletBalance + data.Quantity
-	err = c.State().Put(receiver.UserAddresses[0].UserID, receiver)
+	// update receiver wallet, crediting the address which was targeted
+	_, err = allocateBalance(&receiver)
+	if err != nil {
+		return nil, err
+	}
+	to, _ := findAddress(receiver.UserAddresses, data.To)
+	err = credit(&receiver, to, data.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	err = c.State().Put(receiverID, receiver)
 	if err != nil {
 		return nil, err
 	}
@@ -734,15 +955,6 @@ func GetAddressBookLabel(c router.Context) (interface{}, error) {
 	// get the data from the request and parse it as structure
 	data := c.Param(`data`).(AddressBook)
 
-	// Validate the inputed data
-	err := data.Validate()
-	if err != nil {
-		if _, ok := err.(validation.InternalError); ok {
-			return nil, err
-		}
-		return nil, status.ErrStatusUnprocessableEntity.WithValidationError(err.(validation.Errors))
-	}
-
 	var queryLabelString string
 	//If label is empty and address is not empty
 	if (data.Label == "" && data.Address != "") || (data.Label != "" && data.Address != "") {
@@ -753,10 +965,10 @@ func GetAddressBookLabel(c router.Context) (interface{}, error) {
 		queryLabelString = fmt.Sprintf("{\"selector\":{\"user_id\":\"%s\",\"label\":\"%s\",\"doc_type\":\"%s\"}}", data.UserID, data.Label, utils.DocTypeAddressBook)
 	}
 
-	LabelData, _, err := utils.Get(c, queryLabelString, fmt.Sprintf("Record does not exist in your address book."))
+	LabelData, _, err := utils.Get(c, queryLabelString, "Record does not exist in your address book.")
 
 	if LabelData == nil {
-		return nil, status.ErrInternal.WithMessage(fmt.Sprintf("Label does not exist for this address."))
+		return nil, status.ErrLabelNotFound.WithMessagef("Label does not exist for this address.")
 	}
 
 	addressLabel := AddressBook{}
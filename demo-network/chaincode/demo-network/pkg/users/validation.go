@@ -2,6 +2,9 @@
 package users
 
 import (
+	"time"
+
+	"github.com/chaincode/demo-network/pkg/core/rules"
 	"github.com/chaincode/demo-network/pkg/core/utils"
 
 	validation "github.com/go-ozzo/ozzo-validation"
@@ -10,15 +13,16 @@ import (
 // Validate Validates the User Structure
 func (data User) Validate() error {
 	return validation.ValidateStruct(&data,
-		validation.Field(&data.Address, validation.Required.Error(utils.AddressRequired), validation.NotNil.Error(utils.AddressRequired)),
+		validation.Field(&data.Address, rules.Address...),
 	)
 }
 
 // Validate Validates the Address Structure
 func (data Address) Validate() error {
 	return validation.ValidateStruct(&data,
-		validation.Field(&data.Label, validation.Required.Error(utils.AddressRequired), validation.NotNil.Error(utils.AddressRequired)),
-		validation.Field(&data.Value, validation.Required.Error(utils.AddressRequired), validation.NotNil.Error(utils.AddressRequired)),
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Label, rules.Label...),
+		validation.Field(&data.Value, rules.Address...),
 	)
 }
 
@@ -26,16 +30,17 @@ func (data Address) Validate() error {
 func (data Asset) Validate() error {
 	return validation.ValidateStruct(&data,
 		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
-		validation.Field(&data.Label, validation.Required.Error(utils.LabelRequired), validation.NotNil.Error(utils.LabelRequired)),
-		validation.Field(&data.Code, validation.Required.Error(utils.CodeRequired), validation.NotNil.Error(utils.CodeRequired)),
-		validation.Field(&data.Quantity, validation.Required.Error(utils.QuantityRequired), validation.NotNil.Error(utils.QuantityRequired)),
+		validation.Field(&data.Label, rules.Label...),
+		validation.Field(&data.Code, rules.AssetCode...),
+		validation.Field(&data.Quantity, rules.Amount...),
 	)
 }
 
 // Validate Validates the CheckAssetStruct Structure
 func (data CheckAssetStruct) Validate() error {
 	return validation.ValidateStruct(&data,
-		validation.Field(&data.Code, validation.Required.Error(utils.CodeRequired), validation.NotNil.Error(utils.CodeRequired)),
+		validation.Field(&data.Code, rules.AssetCode...),
+		validation.Field(&data.Label, rules.OptionalLabel...),
 	)
 }
 
@@ -50,10 +55,12 @@ func (data UserId) Validate() error {
 func (data GetTransaction) Validate() error {
 	return validation.ValidateStruct(&data,
 		validation.Field(&data.From, validation.Required.Error(utils.IDRequired), validation.NotNil.Error(utils.IDRequired)),
-		validation.Field(&data.To, validation.Required.Error(utils.IDRequired), validation.NotNil.Error(utils.IDRequired)),
-		validation.Field(&data.Code, validation.Required.Error(utils.CodeRequired), validation.NotNil.Error(utils.CodeRequired)),
-		validation.Field(&data.Quantity, validation.Required.Error(utils.QuantityRequired), validation.NotNil.Error(utils.QuantityRequired)),
-		validation.Field(&data.Label, validation.Required.Error(utils.LabelRequired), validation.NotNil.Error(utils.LabelRequired)),
+		validation.Field(&data.FromAddress, rules.OptionalAddress...),
+		validation.Field(&data.To, rules.Recipient...),
+		validation.Field(&data.Code, rules.AssetCode...),
+		validation.Field(&data.Quantity, rules.Amount...),
+		validation.Field(&data.Label, rules.Label...),
+		validation.Field(&data.Memo, rules.Memo...),
 	)
 }
 
@@ -61,9 +68,226 @@ func (data GetTransaction) Validate() error {
 func (data SendBalance) Validate() error {
 	return validation.ValidateStruct(&data,
 		validation.Field(&data.From, validation.Required.Error(utils.IDRequired), validation.NotNil.Error(utils.IDRequired)),
-		validation.Field(&data.To, validation.Required.Error(utils.IDRequired), validation.NotNil.Error(utils.IDRequired)),
-		validation.Field(&data.Quantity, validation.Required.Error(utils.QuantityRequired), validation.NotNil.Error(utils.QuantityRequired)),
-		validation.Field(&data.Label, validation.Required.Error(utils.LabelRequired), validation.NotNil.Error(utils.LabelRequired)),
+		validation.Field(&data.FromAddress, rules.OptionalAddress...),
+		validation.Field(&data.To, rules.Recipient...),
+		validation.Field(&data.Quantity, rules.Amount...),
+		validation.Field(&data.Label, rules.Label...),
+		validation.Field(&data.Memo, rules.Memo...),
+	)
+}
+
+// Validate Validates the MemoKey Structure
+func (data MemoKey) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.PublicKey, validation.Required.Error(utils.PublicKeyRequired)),
+	)
+}
+
+// Validate Validates the PaymentRequest Structure
+func (data PaymentRequest) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Address, rules.OptionalAddress...),
+		validation.Field(&data.PayerAddress, rules.Address...),
+		validation.Field(&data.Code, rules.AssetCode...),
+		validation.Field(&data.Quantity, rules.Amount...),
+		validation.Field(&data.Memo, rules.Memo...),
+		validation.Field(&data.ExpiresAt, validation.Required.Error(utils.ExpiryInvalid), validation.Date(time.RFC3339).Error(utils.ExpiryInvalid)),
+	)
+}
+
+// Validate Validates the PaymentRequestPayment Structure
+func (data PaymentRequestPayment) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.PaymentRequestID, validation.Required.Error(utils.PaymentIDRequired)),
+		validation.Field(&data.FromAddress, rules.OptionalAddress...),
+		validation.Field(&data.Quantity, rules.OptionalAmount...),
+		validation.Field(&data.Label, rules.Label...),
+	)
+}
+
+// Validate Validates the PaymentRequestID Structure
+func (data PaymentRequestID) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.PaymentRequestID, validation.Required.Error(utils.PaymentIDRequired)),
+	)
+}
+
+// Validate Validates the PaymentRequestFilter Structure
+func (data PaymentRequestFilter) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Status, validation.In(utils.PaymentPending, utils.PaymentPartiallyPaid, utils.PaymentSettled, utils.PaymentDeclined, utils.PaymentExpired).Error(utils.StatusInvalid)),
+	)
+}
+
+// Validate Validates the TransferAcceptance Structure
+func (data TransferAcceptance) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+	)
+}
+
+// Validate Validates the PendingTransferID Structure
+func (data PendingTransferID) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.TransferID, validation.Required.Error(utils.TransferIDRequired)),
+	)
+}
+
+// Validate Validates the AssetRestriction Structure
+func (data AssetRestriction) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Code, rules.AssetCode...),
+	)
+}
+
+// Validate Validates the TransferReview Structure
+func (data TransferReview) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.TransferID, validation.Required.Error(utils.TransferIDRequired)),
+		validation.Field(&data.Reason, validation.Required.Error(utils.ReasonRequired), validation.NotNil.Error(utils.ReasonRequired)),
+	)
+}
+
+// Validate Validates the AllowlistMode Structure
+func (data AllowlistMode) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Code, rules.AssetCode...),
+	)
+}
+
+// Validate Validates the AllowlistAddresses Structure
+func (data AllowlistAddresses) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Code, rules.AssetCode...),
+		validation.Field(&data.Addresses, validation.Required.Error(utils.AddressesEmpty), validation.NotNil.Error(utils.AddressesEmpty), rules.Each(rules.Address...)),
+	)
+}
+
+// Validate Validates the AllowlistQuery Structure
+func (data AllowlistQuery) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Code, rules.AssetCode...),
+		validation.Field(&data.Address, rules.OptionalAddress...),
+	)
+}
+
+// Validate Validates the Hold Structure
+func (data Hold) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Address, rules.OptionalAddress...),
+		validation.Field(&data.MerchantAddress, rules.Address...),
+		validation.Field(&data.Code, rules.AssetCode...),
+		validation.Field(&data.Quantity, rules.Amount...),
+		validation.Field(&data.Label, rules.Label...),
+		validation.Field(&data.Memo, rules.Memo...),
+		validation.Field(&data.ExpiresAt, validation.Required.Error(utils.ExpiryInvalid), validation.Date(time.RFC3339).Error(utils.ExpiryInvalid)),
+	)
+}
+
+// Validate Validates the HoldCapture Structure
+func (data HoldCapture) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.HoldID, validation.Required.Error(utils.HoldIDRequired)),
+		validation.Field(&data.Quantity, rules.OptionalAmount...),
+	)
+}
+
+// Validate Validates the HoldID Structure
+func (data HoldID) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.HoldID, validation.Required.Error(utils.HoldIDRequired)),
+	)
+}
+
+// Validate Validates the Escrow Structure
+func (data Escrow) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.BuyerID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.BuyerAddress, rules.OptionalAddress...),
+		validation.Field(&data.SellerAddress, rules.Address...),
+		validation.Field(&data.Code, rules.AssetCode...),
+		validation.Field(&data.Quantity, rules.Amount...),
+		validation.Field(&data.Memo, rules.Memo...),
+		validation.Field(&data.ExpiresAt, validation.Required.Error(utils.ExpiryInvalid), validation.Date(time.RFC3339).Error(utils.ExpiryInvalid)),
+	)
+}
+
+// Validate Validates the EscrowAction Structure
+func (data EscrowAction) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.EscrowID, validation.Required.Error(utils.EscrowIDRequired)),
+	)
+}
+
+// Validate Validates the EscrowDecision Structure
+func (data EscrowDecision) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.EscrowID, validation.Required.Error(utils.EscrowIDRequired)),
+		validation.Field(&data.SellerQuantity, rules.OptionalAmount...),
+		validation.Field(&data.Reason, validation.Required.Error(utils.ReasonRequired), validation.NotNil.Error(utils.ReasonRequired)),
+	)
+}
+
+// Validate Validates the NameRegistration Structure
+func (data NameRegistration) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Name, rules.AddressName...),
+		validation.Field(&data.Address, rules.Address...),
+	)
+}
+
+// Validate Validates the NameID Structure
+func (data NameID) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Name, rules.AddressName...),
+	)
+}
+
+// Validate Validates the NameTransfer Structure
+func (data NameTransfer) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Name, rules.AddressName...),
+		validation.Field(&data.ToAddress, rules.Address...),
+	)
+}
+
+// Validate Validates the NameQuery Structure
+func (data NameQuery) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Name, rules.AddressName...),
+	)
+}
+
+// Validate Validates the NameLookup Structure
+func (data NameLookup) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Address, rules.Address...),
+	)
+}
+
+// Validate Validates the PrivateMemo Structure
+func (data PrivateMemo) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Receiver, append([]validation.Rule{validation.Required.Error(utils.MemoRequired)}, rules.EncryptedMemo...)...),
+		validation.Field(&data.Sender, rules.EncryptedMemo...),
 	)
 }
 
@@ -71,14 +295,192 @@ func (data SendBalance) Validate() error {
 func (data AddressBook) Validate() error {
 	return validation.ValidateStruct(&data,
 		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
-		validation.Field(&data.Address, validation.NotNil.Error(utils.AddressRequired)),
-		validation.Field(&data.Label, validation.NotNil.Error(utils.LabelRequired)),
+		validation.Field(&data.Address, rules.OptionalAddress...),
+		validation.Field(&data.Label, rules.OptionalLabel...),
 	)
 }
 
 // Validate Validates the UserSecret Structure
 func (data UserSecret) Validate() error {
 	return validation.ValidateStruct(&data,
-		validation.Field(&data.Secret, validation.Required.Error(utils.SecretRequired), validation.NotNil.Error(utils.SecretRequired)),
+		validation.Field(&data.Secret, validation.Required.Error(utils.SecretRequired), validation.NotNil.Error(utils.SecretRequired), validation.Length(utils.SecretLength+1, 0).Error(utils.SecretInvalid)),
+	)
+}
+
+// Validate Validates the UserPrivate Structure
+func (data UserPrivate) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Identity, validation.Required.Error(utils.IdentityRequired), validation.NotNil.Error(utils.IdentityRequired)),
+		validation.Field(&data.Secret, validation.Required.Error(utils.SecretRequired), validation.NotNil.Error(utils.SecretRequired), validation.Length(utils.SecretLength, utils.SecretLength).Error(utils.SecretInvalid)),
+	)
+}
+
+// Validate Validates the BlockedAddress Structure
+func (data BlockedAddress) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Address, rules.Address...),
+		validation.Field(&data.Reason, validation.Required.Error(utils.ReasonRequired), validation.NotNil.Error(utils.ReasonRequired)),
+	)
+}
+
+// Validate Validates the BlockedAddresses Structure
+func (data BlockedAddresses) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Addresses, validation.Required.Error(utils.AddressesEmpty), validation.NotNil.Error(utils.AddressesEmpty), rules.Each(rules.Address...)),
+		validation.Field(&data.Reason, validation.Required.Error(utils.ReasonRequired), validation.NotNil.Error(utils.ReasonRequired)),
+	)
+}
+
+// Validate Validates the BlockedAddressID Structure
+func (data BlockedAddressID) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Address, rules.Address...),
+	)
+}
+
+// Validate Validates the MonitoringRules Structure
+func (data MonitoringRules) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Code, rules.AssetCode...),
+		validation.Field(&data.LargeTransferThreshold, rules.Amount...),
+		validation.Field(&data.StructuringMaxQuantity, rules.Amount...),
+		validation.Field(&data.StructuringCount, validation.Required.Error(utils.PositiveRequired), validation.Min(1).Error(utils.PositiveRequired)),
+		validation.Field(&data.StructuringWindow, validation.Required.Error(utils.PositiveRequired), validation.Min(int64(1)).Error(utils.PositiveRequired)),
+		validation.Field(&data.NewCounterpartyThreshold, rules.Amount...),
+	)
+}
+
+// Validate Validates the MonitoringRulesQuery Structure
+func (data MonitoringRulesQuery) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Code, rules.AssetCode...),
+	)
+}
+
+// Validate Validates the AlertFilter Structure
+func (data AlertFilter) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Status, validation.In(utils.AlertStatusOpen, utils.AlertStatusResolved)),
+	)
+}
+
+// Validate Validates the AlertResolution Structure
+func (data AlertResolution) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.AlertID, validation.Required.Error(utils.AlertIDRequired), validation.NotNil.Error(utils.AlertIDRequired)),
+		validation.Field(&data.Resolution, validation.Required.Error(utils.ResolutionRequired), validation.NotNil.Error(utils.ResolutionRequired)),
+	)
+}
+
+// Validate Validates the Contact Structure
+func (data Contact) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Address, rules.Address...),
+		validation.Field(&data.Label, rules.Label...),
+	)
+}
+
+// Validate Validates the ContactAddress Structure
+func (data ContactAddress) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Address, rules.Address...),
+	)
+}
+
+// Validate Validates the ContactsImport Structure
+func (data ContactsImport) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Format, validation.Required.Error(utils.FormatRequired), validation.In(utils.ContactsFormatCSV, utils.ContactsFormatJSON).Error(utils.FormatInvalid)),
+		validation.Field(&data.Data, validation.Required.Error(utils.DataRequired), validation.NotNil.Error(utils.DataRequired)),
+	)
+}
+
+// Validate Validates the ContactsExport Structure
+func (data ContactsExport) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Format, validation.Required.Error(utils.FormatRequired), validation.In(utils.ContactsFormatCSV, utils.ContactsFormatJSON).Error(utils.FormatInvalid)),
+	)
+}
+
+// Validate Validates the AddressValue Structure
+func (data AddressValue) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Value, rules.Address...),
+	)
+}
+
+// Validate Validates the InternalMove Structure
+func (data InternalMove) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.FromAddress, rules.Address...),
+		validation.Field(&data.ToAddress, rules.Address...),
+		validation.Field(&data.Code, rules.AssetCode...),
+		validation.Field(&data.Quantity, rules.Amount...),
+	)
+}
+
+// Validate Validates the ConfidentialFunds Structure
+func (data ConfidentialFunds) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Address, rules.OptionalAddress...),
+		validation.Field(&data.Collection, validation.Required.Error(utils.CollectionRequired), validation.NotNil.Error(utils.CollectionRequired)),
+		validation.Field(&data.Code, rules.AssetCode...),
+		validation.Field(&data.Quantity, rules.Amount...),
+	)
+}
+
+// Validate Validates the ConfidentialBalance Structure
+func (data ConfidentialBalance) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.UserID, validation.Required.Error(utils.UserIDRequired), validation.NotNil.Error(utils.UserIDRequired)),
+		validation.Field(&data.Collection, validation.Required.Error(utils.CollectionRequired), validation.NotNil.Error(utils.CollectionRequired)),
+		validation.Field(&data.Code, rules.AssetCode...),
+	)
+}
+
+// Validate Validates the ConfidentialTransfer Structure
+func (data ConfidentialTransfer) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Collection, validation.Required.Error(utils.CollectionRequired), validation.NotNil.Error(utils.CollectionRequired)),
+	)
+}
+
+// Validate Validates the ConfidentialTransferDetails Structure
+func (data ConfidentialTransferDetails) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.From, validation.Required.Error(utils.IDRequired), validation.NotNil.Error(utils.IDRequired)),
+		validation.Field(&data.To, rules.Address...),
+		validation.Field(&data.Code, rules.AssetCode...),
+		validation.Field(&data.Quantity, rules.Amount...),
+		validation.Field(&data.Salt, validation.Required.Error(utils.SaltInvalid), validation.Length(utils.SaltLength, 0).Error(utils.SaltInvalid)),
+	)
+}
+
+// Validate Validates the CommitmentReference Structure
+func (data CommitmentReference) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Reference, validation.Required.Error(utils.ReferenceRequired), validation.NotNil.Error(utils.ReferenceRequired)),
+	)
+}
+
+// Validate Validates the KeyEndorsement Structure
+func (data KeyEndorsement) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Key, validation.Required.Error(utils.KeyRequired), validation.NotNil.Error(utils.KeyRequired)),
+		validation.Field(&data.Orgs, validation.Required.Error(utils.OrgsRequired), validation.NotNil.Error(utils.OrgsRequired)),
+	)
+}
+
+// Validate Validates the EndorsementKey Structure
+func (data EndorsementKey) Validate() error {
+	return validation.ValidateStruct(&data,
+		validation.Field(&data.Key, validation.Required.Error(utils.KeyRequired), validation.NotNil.Error(utils.KeyRequired)),
 	)
 }
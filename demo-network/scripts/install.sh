@@ -7,6 +7,6 @@ peer channel update -o orderer.example.com:7050 -c $CHANNEL_NAME -f ./channel-ar
 
 peer chaincode install -n walletdemo -v 1.0 -p github.com/chaincode/demo-network/cmd/
 
-peer chaincode instantiate -o orderer.example.com:7050 --tls --cafile /opt/gopath/src/github.com/hyperledger/fabric/peer/crypto/ordererOrganizations/example.com/orderers/orderer.example.com/msp/tlscacerts/tlsca.example.com-cert.pem -C $CHANNEL_NAME -n walletdemo -v 1.0 -c '{"Args":["initLedger"]}' -P "OR ('Org1MSP.peer')"
+peer chaincode instantiate -o orderer.example.com:7050 --tls --cafile /opt/gopath/src/github.com/hyperledger/fabric/peer/crypto/ordererOrganizations/example.com/orderers/orderer.example.com/msp/tlscacerts/tlsca.example.com-cert.pem -C $CHANNEL_NAME -n walletdemo -v 1.0 -c '{"Args":["initLedger"]}' -P "OR ('Org1MSP.peer')" --collections-config /opt/gopath/src/github.com/chaincode/demo-network/collections_config.json
 
-#peer chaincode upgrade -o orderer.example.com:7050 --tls --cafile /opt/gopath/src/github.com/hyperledger/fabric/peer/crypto/ordererOrganizations/example.com/orderers/orderer.example.com/msp/tlscacerts/tlsca.example.com-cert.pem -C mychannel -n walletdemo -v 1.1 -c '{"Args":["initLedger"]}' -P "OR ('Org1MSP.peer')"
\ No newline at end of file
+#peer chaincode upgrade -o orderer.example.com:7050 --tls --cafile /opt/gopath/src/github.com/hyperledger/fabric/peer/crypto/ordererOrganizations/example.com/orderers/orderer.example.com/msp/tlscacerts/tlsca.example.com-cert.pem -C mychannel -n walletdemo -v 1.1 -c '{"Args":["initLedger"]}' -P "OR ('Org1MSP.peer')" --collections-config /opt/gopath/src/github.com/chaincode/demo-network/collections_config.json
\ No newline at end of file